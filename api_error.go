@@ -0,0 +1,91 @@
+package wiremock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrStubNotFound is matched by an *APIError returned when the stub mapping does not exist.
+	ErrStubNotFound = errors.New("stub not found")
+	// ErrInvalidStub is matched by an *APIError returned when WireMock rejects the stub mapping.
+	ErrInvalidStub = errors.New("invalid stub")
+	// ErrRequestNotFound is matched by an *APIError returned when the request does not exist in the journal.
+	ErrRequestNotFound = errors.New("request not found")
+	// ErrScenarioNotFound is matched by an *APIError returned when the scenario does not exist.
+	ErrScenarioNotFound = errors.New("scenario not found")
+	// ErrFileNotFound is matched by an *APIError returned when the body file does not exist.
+	ErrFileNotFound = errors.New("file not found")
+)
+
+// stubErrors maps response statuses of the mappings endpoints to sentinel errors.
+var stubErrors = map[int]error{
+	http.StatusNotFound:            ErrStubNotFound,
+	http.StatusUnprocessableEntity: ErrInvalidStub,
+}
+
+// requestErrors maps response statuses of the requests endpoints to sentinel errors.
+var requestErrors = map[int]error{
+	http.StatusNotFound: ErrRequestNotFound,
+}
+
+// scenarioErrors maps response statuses of the scenarios endpoints to sentinel errors.
+var scenarioErrors = map[int]error{
+	http.StatusNotFound: ErrScenarioNotFound,
+}
+
+// fileErrors maps response statuses of the files endpoints to sentinel errors.
+var fileErrors = map[int]error{
+	http.StatusNotFound: ErrFileNotFound,
+}
+
+// APIError is returned when the WireMock admin API responds with an unexpected status.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+	Errors     []APIErrorDetail
+	sentinel   error
+}
+
+// APIErrorDetail is an entry of the errors array returned by WireMock.
+type APIErrorDetail struct {
+	Code   int             `json:"code,omitempty"`
+	Title  string          `json:"title,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+	Source *APIErrorSource `json:"source,omitempty"`
+}
+
+// APIErrorSource points to the part of the request body which caused the error.
+type APIErrorSource struct {
+	Pointer string `json:"pointer,omitempty"`
+}
+
+// newAPIError builds *APIError from the response, sentinels maps response statuses to errors matched by errors.Is.
+func newAPIError(statusCode int, body []byte, sentinels map[int]error) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       body,
+		sentinel:   sentinels[statusCode],
+	}
+
+	var response struct {
+		Errors []APIErrorDetail `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err == nil {
+		apiErr.Errors = response.Errors
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("bad response status: %d, response: %s", e.StatusCode, string(e.Body))
+}
+
+// Unwrap returns the sentinel error matching the response status, if any.
+func (e *APIError) Unwrap() error {
+	return e.sentinel
+}
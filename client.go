@@ -2,51 +2,203 @@ package wiremock
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/wiremock/go-wiremock/journal"
 )
 
 const (
-	wiremockAdminURN         = "__admin"
-	wiremockAdminMappingsURN = "__admin/mappings"
-	wiremockAdminRequestsURN = "__admin/requests"
+	wiremockAdminURN          = "__admin"
+	wiremockAdminMappingsURN  = "__admin/mappings"
+	wiremockAdminRequestsURN  = "__admin/requests"
+	wiremockAdminScenariosURN = "__admin/scenarios"
+	wiremockAdminSettingsURN  = "__admin/settings"
+	wiremockAdminFilesURN     = "__admin/files"
 )
 
+// journalTimeFormat is the ISO 8601 format of the since parameter of the journal.
+const journalTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// Duplicate policies of stubs import.
+const (
+	DuplicatePolicyOverwrite DuplicatePolicy = "OVERWRITE"
+	DuplicatePolicyIgnore    DuplicatePolicy = "IGNORE"
+)
+
+// DuplicatePolicy is enum of how imported stubs having the ID of an existing stub are handled.
+type DuplicatePolicy string
+
+// ImportOptions configures ImportStubs.
+type ImportOptions struct {
+	DuplicatePolicy      DuplicatePolicy `json:"duplicatePolicy,omitempty"`
+	DeleteAllNotInImport bool            `json:"deleteAllNotInImport,omitempty"`
+}
+
+// RequestsQuery filters requests of the journal.
+type RequestsQuery struct {
+	// Since limits requests to the ones logged after the given time.
+	Since time.Time
+	// Limit limits the number of the most recent requests.
+	Limit int
+	// Unmatched limits requests to the ones not matched by any stub.
+	Unmatched bool
+}
+
+func (q RequestsQuery) values() url.Values {
+	values := url.Values{}
+	if !q.Since.IsZero() {
+		values.Set("since", q.Since.UTC().Format(journalTimeFormat))
+	}
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Unmatched {
+		values.Set("unmatched", "true")
+	}
+	return values
+}
+
 // A Client implements requests to the wiremock server.
 type Client struct {
-	url string
+	url        string
+	httpClient *http.Client
+	timeout    time.Duration
+	headers    http.Header
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the *http.Client used to call the admin API, a nil client is ignored.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithTimeout sets the default timeout applied to every admin API call.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithHeader adds a header sent with every admin API call.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
 }
 
 // NewClient returns *Client.
-func NewClient(url string) *Client {
-	return &Client{url: url}
+func NewClient(url string, opts ...ClientOption) *Client {
+	c := &Client{
+		url:        url,
+		httpClient: http.DefaultClient,
+		headers:    http.Header{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// doRequest sends a request with JSON body to the admin API and returns the response status code and body.
+func (c *Client) doRequest(ctx context.Context, method, urn string, body []byte) (int, []byte, error) {
+	if body == nil {
+		return c.doRawRequest(ctx, method, urn, nil, "")
+	}
+
+	return c.doRawRequest(ctx, method, urn, bytes.NewReader(body), "application/json")
+}
+
+// doRawRequest sends a request with body of the content type to the admin API and returns the response status code and body.
+func (c *Client) doRawRequest(ctx context.Context, method, urn string, body io.Reader, contentType string) (int, []byte, error) {
+	res, cancel, err := c.openRequest(ctx, method, urn, body, contentType)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer cancel()
+	defer res.Body.Close() //nolint:errcheck
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, nil, fmt.Errorf("read response error: %w", err)
+	}
+
+	return res.StatusCode, bodyBytes, nil
+}
+
+// openRequest sends a request to the admin API and returns the response with unread body.
+// The returned cancel func must be called after the body is closed.
+func (c *Client) openRequest(ctx context.Context, method, urn string, body io.Reader, contentType string) (*http.Response, context.CancelFunc, error) {
+	cancel := context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", c.url, urn), body)
+	if err != nil {
+		cancel()
+		return nil, nil, fmt.Errorf("build request error: %w", err)
+	}
+
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return res, cancel, nil
 }
 
 // StubFor creates a new stub mapping.
 func (c *Client) StubFor(stubRule *StubRule) error {
+	return c.StubForCtx(context.Background(), stubRule)
+}
+
+// StubForCtx creates a new stub mapping.
+func (c *Client) StubForCtx(ctx context.Context, stubRule *StubRule) error {
 	requestBody, err := stubRule.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("build stub request error: %w", err)
 	}
 
-	res, err := http.Post(fmt.Sprintf("%s/%s", c.url, wiremockAdminMappingsURN), "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
+	if err := c.uploadBodyFiles(ctx, stubRule); err != nil {
 		return fmt.Errorf("stub request error: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
 
-	if res.StatusCode != http.StatusCreated {
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return fmt.Errorf("read response error: %w", err)
-		}
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, wiremockAdminMappingsURN, requestBody)
+	if err != nil {
+		return fmt.Errorf("stub request error: %w", err)
+	}
 
-		return fmt.Errorf("bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusCreated {
+		return fmt.Errorf("stub for: %w", newAPIError(status, bodyBytes, stubErrors))
 	}
 
 	return nil
@@ -54,19 +206,18 @@ func (c *Client) StubFor(stubRule *StubRule) error {
 
 // Clear deletes all stub mappings.
 func (c *Client) Clear() error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", c.url, wiremockAdminMappingsURN), nil)
-	if err != nil {
-		return fmt.Errorf("build clear Request error: %w", err)
-	}
+	return c.ClearCtx(context.Background())
+}
 
-	res, err := (&http.Client{}).Do(req)
+// ClearCtx deletes all stub mappings.
+func (c *Client) ClearCtx(ctx context.Context) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodDelete, wiremockAdminMappingsURN, nil)
 	if err != nil {
 		return fmt.Errorf("clear Request error: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
 
-	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("bad response status: %d", res.StatusCode)
+	if status != http.StatusOK {
+		return fmt.Errorf("clear: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	return nil
@@ -74,19 +225,18 @@ func (c *Client) Clear() error {
 
 // Reset restores stub mappings to the defaults defined back in the backing store.
 func (c *Client) Reset() error {
-	res, err := http.Post(fmt.Sprintf("%s/%s/reset", c.url, wiremockAdminMappingsURN), "application/json", nil)
+	return c.ResetCtx(context.Background())
+}
+
+// ResetCtx restores stub mappings to the defaults defined back in the backing store.
+func (c *Client) ResetCtx(ctx context.Context) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/reset", wiremockAdminMappingsURN), nil)
 	if err != nil {
 		return fmt.Errorf("reset Request error: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
-
-	if res.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return fmt.Errorf("read response error: %w", err)
-		}
 
-		return fmt.Errorf("bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return fmt.Errorf("reset: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	return nil
@@ -94,19 +244,115 @@ func (c *Client) Reset() error {
 
 // ResetAllScenarios resets back to start of the state of all configured scenarios.
 func (c *Client) ResetAllScenarios() error {
-	res, err := http.Post(fmt.Sprintf("%s/%s/scenarios/reset", c.url, wiremockAdminURN), "application/json", nil)
+	return c.ResetAllScenariosCtx(context.Background())
+}
+
+// ResetAllScenariosCtx resets back to start of the state of all configured scenarios.
+func (c *Client) ResetAllScenariosCtx(ctx context.Context) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/reset", wiremockAdminScenariosURN), nil)
 	if err != nil {
 		return fmt.Errorf("reset all scenarios Request error: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
 
-	if res.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return fmt.Errorf("read response error: %w", err)
-		}
+	if status != http.StatusOK {
+		return fmt.Errorf("reset all scenarios: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	return nil
+}
+
+// GetAllScenarios returns all scenarios with their current state.
+func (c *Client) GetAllScenarios() (*journal.GetAllScenariosResponse, error) {
+	return c.GetAllScenariosCtx(context.Background())
+}
+
+// GetAllScenariosCtx returns all scenarios with their current state.
+func (c *Client) GetAllScenariosCtx(ctx context.Context) (*journal.GetAllScenariosResponse, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, wiremockAdminScenariosURN, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get all scenarios: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("get all scenarios: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response journal.GetAllScenariosResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("get all scenarios: error unmarshalling response: %w", err)
+	}
+	return &response, nil
+}
+
+// SetScenarioState sets the current state of the scenario.
+func (c *Client) SetScenarioState(name, state string) error {
+	return c.SetScenarioStateCtx(context.Background(), name, state)
+}
+
+// SetScenarioStateCtx sets the current state of the scenario.
+func (c *Client) SetScenarioStateCtx(ctx context.Context, name, state string) error {
+	requestBody, err := json.Marshal(map[string]string{"state": state})
+	if err != nil {
+		return fmt.Errorf("set scenario state: build error: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%s/state", wiremockAdminScenariosURN, url.PathEscape(name)), requestBody)
+	if err != nil {
+		return fmt.Errorf("set scenario state: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("set scenario state: %w", newAPIError(status, bodyBytes, scenarioErrors))
+	}
+
+	return nil
+}
+
+// ResetScenario resets the scenario back to the Started state.
+func (c *Client) ResetScenario(name string) error {
+	return c.ResetScenarioCtx(context.Background(), name)
+}
+
+// ResetScenarioCtx resets the scenario back to the Started state.
+func (c *Client) ResetScenarioCtx(ctx context.Context, name string) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%s/state", wiremockAdminScenariosURN, url.PathEscape(name)), nil)
+	if err != nil {
+		return fmt.Errorf("reset scenario: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("reset scenario: %w", newAPIError(status, bodyBytes, scenarioErrors))
+	}
+
+	return nil
+}
+
+// StubForScenario validates the scenario and creates all of its stub mappings in a single request.
+func (c *Client) StubForScenario(scenario *Scenario) error {
+	return c.StubForScenarioCtx(context.Background(), scenario)
+}
+
+// StubForScenarioCtx validates the scenario and creates all of its stub mappings in a single request.
+func (c *Client) StubForScenarioCtx(ctx context.Context, scenario *Scenario) error {
+	if err := scenario.Validate(); err != nil {
+		return fmt.Errorf("stub for scenario: %w", err)
+	}
+
+	return c.ImportStubs(ctx, scenario.Stubs(), ImportOptions{DuplicatePolicy: DuplicatePolicyOverwrite})
+}
 
-		return fmt.Errorf("bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+// DeleteScenario deletes all stub mappings of the scenario.
+func (c *Client) DeleteScenario(scenario *Scenario) error {
+	return c.DeleteScenarioCtx(context.Background(), scenario)
+}
+
+// DeleteScenarioCtx deletes all stub mappings of the scenario.
+func (c *Client) DeleteScenarioCtx(ctx context.Context, scenario *Scenario) error {
+	for _, stub := range scenario.Stubs() {
+		if err := c.DeleteStubCtx(ctx, stub); err != nil {
+			return fmt.Errorf("delete scenario %q: %w", scenario.Name(), err)
+		}
 	}
 
 	return nil
@@ -114,24 +360,23 @@ func (c *Client) ResetAllScenarios() error {
 
 // GetCountRequests gives count requests by criteria.
 func (c *Client) GetCountRequests(r *Request) (int64, error) {
+	return c.GetCountRequestsCtx(context.Background(), r)
+}
+
+// GetCountRequestsCtx gives count requests by criteria.
+func (c *Client) GetCountRequestsCtx(ctx context.Context, r *Request) (int64, error) {
 	requestBody, err := r.MarshalJSON()
 	if err != nil {
 		return 0, fmt.Errorf("get count requests: build error: %w", err)
 	}
 
-	res, err := http.Post(fmt.Sprintf("%s/%s/requests/count", c.url, wiremockAdminURN), "application/json", bytes.NewBuffer(requestBody))
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/count", wiremockAdminRequestsURN), requestBody)
 	if err != nil {
 		return 0, fmt.Errorf("get count requests: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
-
-	bodyBytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		return 0, fmt.Errorf("get count requests: read response error: %w", err)
-	}
 
-	if res.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("get count requests: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return 0, fmt.Errorf("get count requests: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	var countRequestsResponse struct {
@@ -148,7 +393,12 @@ func (c *Client) GetCountRequests(r *Request) (int64, error) {
 
 // Verify checks count of request sent.
 func (c *Client) Verify(r *Request, expectedCount int64) (bool, error) {
-	actualCount, err := c.GetCountRequests(r)
+	return c.VerifyCtx(context.Background(), r, expectedCount)
+}
+
+// VerifyCtx checks count of request sent.
+func (c *Client) VerifyCtx(ctx context.Context, r *Request, expectedCount int64) (bool, error) {
+	actualCount, err := c.GetCountRequestsCtx(ctx, r)
 	if err != nil {
 		return false, err
 	}
@@ -156,46 +406,259 @@ func (c *Client) Verify(r *Request, expectedCount int64) (bool, error) {
 	return actualCount == expectedCount, nil
 }
 
+// VerifyThat checks that the number of requests matching r satisfies the count matcher.
+// On failure it returns *VerificationError describing the closest near misses and the received requests.
+func (c *Client) VerifyThat(r *Request, expected CountMatcher) error {
+	return c.VerifyThatCtx(context.Background(), r, expected)
+}
+
+// VerifyThatCtx checks that the number of requests matching r satisfies the count matcher.
+// On failure it returns *VerificationError describing the closest near misses and the received requests.
+func (c *Client) VerifyThatCtx(ctx context.Context, r *Request, expected CountMatcher) error {
+	count, err := c.GetCountRequestsCtx(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	if expected.Match(count) {
+		return nil
+	}
+
+	return c.verificationError(ctx, r, expected, count)
+}
+
+// VerifyEventually polls the journal every pollInterval until the number of requests matching r satisfies the count matcher.
+// Failed polls, e.g. while WireMock restarts, are retried until ctx ends. A non-positive pollInterval defaults to 100ms.
+// When ctx ends first, it returns an error wrapping both the context error and *VerificationError describing the last observed journal state,
+// and the error of the last poll when it failed. When no poll succeeded, the journal state is unknown,
+// so the error wraps the context error and the error of the last poll only.
+func (c *Client) VerifyEventually(ctx context.Context, r *Request, expected CountMatcher, pollInterval time.Duration) error {
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var (
+		count    int64
+		observed bool
+		lastErr  error
+	)
+	for {
+		lastCount, err := c.GetCountRequestsCtx(ctx, r)
+		if err == nil {
+			if expected.Match(lastCount) {
+				return nil
+			}
+			count = lastCount
+			observed = true
+		}
+		if ctx.Err() == nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if !observed {
+				if lastErr != nil {
+					return fmt.Errorf("verify eventually: %w: %w", ctx.Err(), lastErr)
+				}
+				return fmt.Errorf("verify eventually: %w", ctx.Err())
+			}
+
+			diagnosticsCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), verificationDiagnosticsTimeout)
+			defer cancel()
+
+			verificationErr := c.verificationError(diagnosticsCtx, r, expected, count)
+			if lastErr != nil {
+				return fmt.Errorf("verify eventually: %w: %w, last poll error: %w", ctx.Err(), verificationErr, lastErr)
+			}
+			return fmt.Errorf("verify eventually: %w: %w", ctx.Err(), verificationErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForRequests blocks until at least n requests matching r are received or ctx ends.
+func (c *Client) WaitForRequests(ctx context.Context, r *Request, n int64) error {
+	return c.VerifyEventually(ctx, r, AtLeast(n), defaultPollInterval)
+}
+
+// verificationError builds *VerificationError, the near misses and the received requests are collected on a best-effort basis.
+func (c *Client) verificationError(ctx context.Context, r *Request, expected CountMatcher, count int64) *VerificationError {
+	verificationErr := &VerificationError{
+		Pattern:  r.describe(),
+		Expected: expected,
+		Count:    count,
+	}
+
+	if nearMisses, err := c.FindNearMissesForPatternCtx(ctx, r); err == nil {
+		verificationErr.NearMisses = nearMisses.NearMisses
+	}
+
+	if requests, err := c.GetRequests(ctx, RequestsQuery{Limit: maxVerificationRequests}); err == nil {
+		verificationErr.Requests = requests.Requests
+		verificationErr.TotalRequests = requests.Meta.Total
+	}
+
+	return verificationErr
+}
+
 // GetAllRequests returns all requests logged in the journal.
 func (c *Client) GetAllRequests() (*journal.GetAllRequestsResponse, error) {
-	res, err := http.Get(fmt.Sprintf("%s/%s", c.url, wiremockAdminRequestsURN))
+	return c.GetAllRequestsCtx(context.Background())
+}
+
+// GetAllRequestsCtx returns all requests logged in the journal.
+func (c *Client) GetAllRequestsCtx(ctx context.Context) (*journal.GetAllRequestsResponse, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, wiremockAdminRequestsURN, nil)
 	if err != nil {
 		return nil, fmt.Errorf("get all requests: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
 
-	bodyBytes, err := io.ReadAll(res.Body)
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("get all requests: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response journal.GetAllRequestsResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("get all requests: error unmarshalling response: %w", err)
+	}
+	return &response, nil
+}
+
+// GetRequests returns requests of the journal matching the query.
+func (c *Client) GetRequests(ctx context.Context, query RequestsQuery) (*journal.GetAllRequestsResponse, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, requestsURN(query), nil)
 	if err != nil {
-		return nil, fmt.Errorf("get all requests: read response error: %w", err)
+		return nil, fmt.Errorf("get requests: %w", err)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get all requests: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("get requests: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	var response journal.GetAllRequestsResponse
 	err = json.Unmarshal(bodyBytes, &response)
 	if err != nil {
-		return nil, fmt.Errorf("get all requests: error unmarshalling response: %w", err)
+		return nil, fmt.Errorf("get requests: error unmarshalling response: %w", err)
 	}
 	return &response, nil
 }
 
-// GetRequestByID retrieves a single request from the journal, by its ID.
-func (c *Client) GetRequestByID(requestID string) (*journal.GetRequestResponse, error) {
-	res, err := http.Get(fmt.Sprintf("%s/%s/%s", c.url, wiremockAdminRequestsURN, requestID))
+// Requests returns an iterator over requests of the journal matching the query.
+// Requests are decoded one at a time while the response is streamed, so the whole journal is never held in memory.
+// Iteration stops after the first error.
+func (c *Client) Requests(ctx context.Context, query RequestsQuery) iter.Seq2[journal.GetRequestResponse, error] {
+	return func(yield func(journal.GetRequestResponse, error) bool) {
+		res, cancel, err := c.openRequest(ctx, http.MethodGet, requestsURN(query), nil, "")
+		if err != nil {
+			yield(journal.GetRequestResponse{}, fmt.Errorf("get requests: request error: %w", err))
+			return
+		}
+		defer cancel()
+		defer res.Body.Close() //nolint:errcheck
+
+		if res.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			yield(journal.GetRequestResponse{}, fmt.Errorf("get requests: %w", newAPIError(res.StatusCode, bodyBytes, nil)))
+			return
+		}
+
+		decoder := json.NewDecoder(res.Body)
+		found, err := seekJSONArray(decoder, "requests")
+		if err != nil {
+			yield(journal.GetRequestResponse{}, fmt.Errorf("get requests: read json error: %w", err))
+			return
+		}
+
+		if !found {
+			return
+		}
+
+		for decoder.More() {
+			var request journal.GetRequestResponse
+			if err := decoder.Decode(&request); err != nil {
+				yield(journal.GetRequestResponse{}, fmt.Errorf("get requests: read json error: %w", err))
+				return
+			}
+
+			if !yield(request, nil) {
+				return
+			}
+		}
+	}
+}
+
+// seekJSONArray advances the decoder to the first element of the array in the field of the top-level object.
+func seekJSONArray(decoder *json.Decoder, field string) (bool, error) {
+	token, err := decoder.Token()
 	if err != nil {
-		return nil, fmt.Errorf("get request by id: build request error: %w", err)
+		return false, err
 	}
-	defer res.Body.Close() //nolint:errcheck
 
-	bodyBytes, err := io.ReadAll(res.Body)
+	if token != json.Delim('{') {
+		return false, fmt.Errorf("expected object, got %v", token)
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return false, err
+		}
+
+		if key != field {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		token, err := decoder.Token()
+		if err != nil {
+			return false, err
+		}
+
+		if token == nil {
+			return false, nil
+		}
+
+		if token != json.Delim('[') {
+			return false, fmt.Errorf("expected array in field %q, got %v", field, token)
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func requestsURN(query RequestsQuery) string {
+	values := query.values()
+	if len(values) == 0 {
+		return wiremockAdminRequestsURN
+	}
+
+	return fmt.Sprintf("%s?%s", wiremockAdminRequestsURN, values.Encode())
+}
+
+// GetRequestByID retrieves a single request from the journal, by its ID.
+func (c *Client) GetRequestByID(requestID string) (*journal.GetRequestResponse, error) {
+	return c.GetRequestByIDCtx(context.Background(), requestID)
+}
+
+// GetRequestByIDCtx retrieves a single request from the journal, by its ID.
+func (c *Client) GetRequestByIDCtx(ctx context.Context, requestID string) (*journal.GetRequestResponse, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", wiremockAdminRequestsURN, requestID), nil)
 	if err != nil {
-		return nil, fmt.Errorf("get request by id: read response error: %w", err)
+		return nil, fmt.Errorf("get request by id: request error: %w", err)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("get request by id: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("get request by id: %w", newAPIError(status, bodyBytes, requestErrors))
 	}
 
 	var response journal.GetRequestResponse
@@ -208,24 +671,23 @@ func (c *Client) GetRequestByID(requestID string) (*journal.GetRequestResponse,
 
 // FindRequestsByCriteria returns all requests in the journal matching the criteria.
 func (c *Client) FindRequestsByCriteria(r *Request) (*journal.FindRequestsByCriteriaResponse, error) {
+	return c.FindRequestsByCriteriaCtx(context.Background(), r)
+}
+
+// FindRequestsByCriteriaCtx returns all requests in the journal matching the criteria.
+func (c *Client) FindRequestsByCriteriaCtx(ctx context.Context, r *Request) (*journal.FindRequestsByCriteriaResponse, error) {
 	requestBody, err := r.MarshalJSON()
 	if err != nil {
 		return nil, fmt.Errorf("find requests by criteria: build error: %w", err)
 	}
 
-	res, err := http.Post(fmt.Sprintf("%s/%s/find", c.url, wiremockAdminRequestsURN), "application/json", bytes.NewBuffer(requestBody))
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/find", wiremockAdminRequestsURN), requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("find requests by criteria: request error: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
-
-	bodyBytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("find requests by criteria: read response error: %w", err)
-	}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find requests by criteria: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("find requests by criteria: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	var requests journal.FindRequestsByCriteriaResponse
@@ -238,19 +700,18 @@ func (c *Client) FindRequestsByCriteria(r *Request) (*journal.FindRequestsByCrit
 
 // FindUnmatchedRequests returns all requests in the journal matching the criteria.
 func (c *Client) FindUnmatchedRequests() (*journal.FindUnmatchedRequestsResponse, error) {
-	res, err := http.Get(fmt.Sprintf("%s/%s/unmatched", c.url, wiremockAdminRequestsURN))
-	if err != nil {
-		return nil, fmt.Errorf("find unmatched requests: request error: %w", err)
-	}
-	defer res.Body.Close() //nolint:errcheck
+	return c.FindUnmatchedRequestsCtx(context.Background())
+}
 
-	bodyBytes, err := io.ReadAll(res.Body)
+// FindUnmatchedRequestsCtx returns all requests in the journal matching the criteria.
+func (c *Client) FindUnmatchedRequestsCtx(ctx context.Context) (*journal.FindUnmatchedRequestsResponse, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/unmatched", wiremockAdminRequestsURN), nil)
 	if err != nil {
-		return nil, fmt.Errorf("find unmatched requests: read response error: %w", err)
+		return nil, fmt.Errorf("find unmatched requests: request error: %w", err)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("find unmatched requests: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("find unmatched requests: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	var requests journal.FindUnmatchedRequestsResponse
@@ -261,72 +722,143 @@ func (c *Client) FindUnmatchedRequests() (*journal.FindUnmatchedRequestsResponse
 	return &requests, nil
 }
 
-// DeleteAllRequests deletes all the requests in the journal.
-func (c *Client) DeleteAllRequests() error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", c.url, wiremockAdminRequestsURN), nil)
-	if err != nil {
-		return fmt.Errorf("delete all requests: build error: %w", err)
-	}
-	res, err := (&http.Client{}).Do(req)
+// FindNearMissesForUnmatched returns the stub mappings closest to each request not matched by any stub.
+func (c *Client) FindNearMissesForUnmatched() (*journal.FindNearMissesResponse, error) {
+	return c.FindNearMissesForUnmatchedCtx(context.Background())
+}
 
+// FindNearMissesForUnmatchedCtx returns the stub mappings closest to each request not matched by any stub.
+func (c *Client) FindNearMissesForUnmatchedCtx(ctx context.Context) (*journal.FindNearMissesResponse, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/unmatched/near-misses", wiremockAdminRequestsURN), nil)
 	if err != nil {
-		return fmt.Errorf("delete all requests: request error: %w", err)
+		return nil, fmt.Errorf("find near misses for unmatched: request error: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
 
-	if res.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return fmt.Errorf("read response error: %w", err)
-		}
-		return fmt.Errorf("bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("find near misses for unmatched: %w", newAPIError(status, bodyBytes, nil))
 	}
-	return nil
-}
 
-// DeleteRequestByID deletes a single request from the journal, by its ID.
-func (c *Client) DeleteRequestByID(requestID string) error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s/%s", c.url, wiremockAdminRequestsURN, requestID), nil)
+	var response journal.FindNearMissesResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("find near misses for unmatched: read json error: %w", err)
+	}
+	return &response, nil
+}
+
+// FindNearMissesForRequest returns the stub mappings closest to the request.
+func (c *Client) FindNearMissesForRequest(r journal.Request) (*journal.FindNearMissesResponse, error) {
+	return c.FindNearMissesForRequestCtx(context.Background(), r)
+}
+
+// FindNearMissesForRequestCtx returns the stub mappings closest to the request.
+func (c *Client) FindNearMissesForRequestCtx(ctx context.Context, r journal.Request) (*journal.FindNearMissesResponse, error) {
+	requestBody, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("find near misses for request: build error: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/near-misses/request", wiremockAdminURN), requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("find near misses for request: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("find near misses for request: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response journal.FindNearMissesResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("find near misses for request: read json error: %w", err)
+	}
+	return &response, nil
+}
+
+// FindNearMissesForPattern returns the requests in the journal closest to the request pattern.
+func (c *Client) FindNearMissesForPattern(r *Request) (*journal.FindNearMissesResponse, error) {
+	return c.FindNearMissesForPatternCtx(context.Background(), r)
+}
+
+// FindNearMissesForPatternCtx returns the requests in the journal closest to the request pattern.
+func (c *Client) FindNearMissesForPatternCtx(ctx context.Context, r *Request) (*journal.FindNearMissesResponse, error) {
+	requestBody, err := r.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("find near misses for pattern: build error: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/near-misses/request-pattern", wiremockAdminURN), requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("find near misses for pattern: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("find near misses for pattern: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response journal.FindNearMissesResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("find near misses for pattern: read json error: %w", err)
+	}
+	return &response, nil
+}
+
+// DeleteAllRequests deletes all the requests in the journal.
+func (c *Client) DeleteAllRequests() error {
+	return c.DeleteAllRequestsCtx(context.Background())
+}
+
+// DeleteAllRequestsCtx deletes all the requests in the journal.
+func (c *Client) DeleteAllRequestsCtx(ctx context.Context) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodDelete, wiremockAdminRequestsURN, nil)
 	if err != nil {
-		return fmt.Errorf("delete request by id: build request error: %w", err)
+		return fmt.Errorf("delete all requests: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("delete all requests: %w", newAPIError(status, bodyBytes, nil))
 	}
+	return nil
+}
+
+// DeleteRequestByID deletes a single request from the journal, by its ID.
+func (c *Client) DeleteRequestByID(requestID string) error {
+	return c.DeleteRequestByIDCtx(context.Background(), requestID)
+}
 
-	res, err := (&http.Client{}).Do(req)
+// DeleteRequestByIDCtx deletes a single request from the journal, by its ID.
+func (c *Client) DeleteRequestByIDCtx(ctx context.Context, requestID string) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", wiremockAdminRequestsURN, requestID), nil)
 	if err != nil {
 		return fmt.Errorf("delete request by id: request error: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
 
-	if res.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return fmt.Errorf("delete request by id: read response error: %w", err)
-		}
-		return fmt.Errorf("delete request by id: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return fmt.Errorf("delete request by id: %w", newAPIError(status, bodyBytes, requestErrors))
 	}
 	return nil
 }
 
 // DeleteRequestsByCriteria deletes all requests in the journal matching the criteria.
 func (c *Client) DeleteRequestsByCriteria(r *Request) (*journal.DeleteRequestByCriteriaResponse, error) {
+	return c.DeleteRequestsByCriteriaCtx(context.Background(), r)
+}
+
+// DeleteRequestsByCriteriaCtx deletes all requests in the journal matching the criteria.
+func (c *Client) DeleteRequestsByCriteriaCtx(ctx context.Context, r *Request) (*journal.DeleteRequestByCriteriaResponse, error) {
 	requestBody, err := r.MarshalJSON()
 	if err != nil {
 		return nil, fmt.Errorf("delete requests by criteria: build error: %w", err)
 	}
 
-	res, err := http.Post(fmt.Sprintf("%s/%s/remove", c.url, wiremockAdminRequestsURN), "application/json", bytes.NewBuffer(requestBody))
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/remove", wiremockAdminRequestsURN), requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("delete requests by criteria: request error: %w", err)
 	}
-	defer res.Body.Close() //nolint:errcheck
 
-	bodyBytes, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("delete requests by criteria: read response error: %w", err)
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("delete requests by criteria: bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("delete requests by criteria: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	var requests journal.DeleteRequestByCriteriaResponse
@@ -339,80 +871,617 @@ func (c *Client) DeleteRequestsByCriteria(r *Request) (*journal.DeleteRequestByC
 
 // DeleteStubByID deletes stub by id.
 func (c *Client) DeleteStubByID(id string) error {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s/%s", c.url, wiremockAdminMappingsURN, id), nil)
+	return c.DeleteStubByIDCtx(context.Background(), id)
+}
+
+// DeleteStubByIDCtx deletes stub by id.
+func (c *Client) DeleteStubByIDCtx(ctx context.Context, id string) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", wiremockAdminMappingsURN, id), nil)
 	if err != nil {
-		return fmt.Errorf("delete stub by id: build request error: %w", err)
+		return fmt.Errorf("delete stub by id: request error: %w", err)
 	}
 
-	res, err := (&http.Client{}).Do(req)
+	if status != http.StatusOK {
+		return fmt.Errorf("delete stub by id: %w", newAPIError(status, bodyBytes, stubErrors))
+	}
+
+	return nil
+}
+
+// DeleteStub deletes stub mapping.
+func (c *Client) DeleteStub(s *StubRule) error {
+	return c.DeleteStubCtx(context.Background(), s)
+}
+
+// DeleteStubCtx deletes stub mapping.
+func (c *Client) DeleteStubCtx(ctx context.Context, s *StubRule) error {
+	return c.DeleteStubByIDCtx(ctx, s.UUID())
+}
+
+// SaveMappings saves all persistent stub mappings to the backing store.
+func (c *Client) SaveMappings() error {
+	return c.SaveMappingsCtx(context.Background())
+}
+
+// SaveMappingsCtx saves all persistent stub mappings to the backing store.
+func (c *Client) SaveMappingsCtx(ctx context.Context) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/save", wiremockAdminMappingsURN), nil)
 	if err != nil {
-		return fmt.Errorf("delete stub by id: request error: %w", err)
+		return fmt.Errorf("save mappings: request error: %w", err)
 	}
 
-	defer res.Body.Close() //nolint:errcheck
+	if status != http.StatusOK {
+		return fmt.Errorf("save mappings: %w", newAPIError(status, bodyBytes, nil))
+	}
 
-	if res.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return fmt.Errorf("read response error: %w", err)
-		}
+	return nil
+}
 
-		return fmt.Errorf("bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+// GetAllStubs returns stub mappings, limit and offset page through them when greater than zero.
+func (c *Client) GetAllStubs(limit, offset int) (*journal.GetAllStubsResponse, error) {
+	return c.GetAllStubsCtx(context.Background(), limit, offset)
+}
+
+// GetAllStubsCtx returns stub mappings, limit and offset page through them when greater than zero.
+func (c *Client) GetAllStubsCtx(ctx context.Context, limit, offset int) (*journal.GetAllStubsResponse, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+
+	urn := wiremockAdminMappingsURN
+	if len(query) > 0 {
+		urn = fmt.Sprintf("%s?%s", urn, query.Encode())
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, urn, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get all stubs: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("get all stubs: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response journal.GetAllStubsResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("get all stubs: error unmarshalling response: %w", err)
+	}
+	return &response, nil
+}
+
+// GetStubByID returns a single stub mapping, by its ID.
+func (c *Client) GetStubByID(id string) (*journal.StubMapping, error) {
+	return c.GetStubByIDCtx(context.Background(), id)
+}
+
+// GetStubByIDCtx returns a single stub mapping, by its ID.
+func (c *Client) GetStubByIDCtx(ctx context.Context, id string) (*journal.StubMapping, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", wiremockAdminMappingsURN, id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get stub by id: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("get stub by id: %w", newAPIError(status, bodyBytes, stubErrors))
+	}
+
+	var response journal.StubMapping
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("get stub by id: error unmarshalling response: %w", err)
+	}
+	return &response, nil
+}
+
+// EditStub replaces the stub mapping having the same ID as stubRule and returns the updated mapping.
+func (c *Client) EditStub(stubRule *StubRule) (*journal.StubMapping, error) {
+	return c.EditStubCtx(context.Background(), stubRule)
+}
+
+// EditStubCtx replaces the stub mapping having the same ID as stubRule and returns the updated mapping.
+func (c *Client) EditStubCtx(ctx context.Context, stubRule *StubRule) (*journal.StubMapping, error) {
+	requestBody, err := stubRule.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("edit stub: build error: %w", err)
+	}
+
+	if err := c.uploadBodyFiles(ctx, stubRule); err != nil {
+		return nil, fmt.Errorf("edit stub: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%s", wiremockAdminMappingsURN, stubRule.UUID()), requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("edit stub: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("edit stub: %w", newAPIError(status, bodyBytes, stubErrors))
+	}
+
+	var response journal.StubMapping
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("edit stub: error unmarshalling response: %w", err)
+	}
+	return &response, nil
+}
+
+// FindStubsByMetadata returns stub mappings with metadata matching the matcher.
+func (c *Client) FindStubsByMetadata(matcher BasicParamMatcher) (*journal.GetAllStubsResponse, error) {
+	return c.FindStubsByMetadataCtx(context.Background(), matcher)
+}
+
+// FindStubsByMetadataCtx returns stub mappings with metadata matching the matcher.
+func (c *Client) FindStubsByMetadataCtx(ctx context.Context, matcher BasicParamMatcher) (*journal.GetAllStubsResponse, error) {
+	requestBody, err := matcher.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("find stubs by metadata: build error: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/find-by-metadata", wiremockAdminMappingsURN), requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("find stubs by metadata: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("find stubs by metadata: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response journal.GetAllStubsResponse
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("find stubs by metadata: error unmarshalling response: %w", err)
+	}
+	return &response, nil
+}
+
+// RemoveStubsByMetadata deletes stub mappings with metadata matching the matcher.
+func (c *Client) RemoveStubsByMetadata(matcher BasicParamMatcher) error {
+	return c.RemoveStubsByMetadataCtx(context.Background(), matcher)
+}
+
+// RemoveStubsByMetadataCtx deletes stub mappings with metadata matching the matcher.
+func (c *Client) RemoveStubsByMetadataCtx(ctx context.Context, matcher BasicParamMatcher) error {
+	requestBody, err := matcher.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("remove stubs by metadata: build error: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/remove-by-metadata", wiremockAdminMappingsURN), requestBody)
+	if err != nil {
+		return fmt.Errorf("remove stubs by metadata: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("remove stubs by metadata: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	return nil
 }
 
-// DeleteStub deletes stub mapping.
-func (c *Client) DeleteStub(s *StubRule) error {
-	return c.DeleteStubByID(s.UUID())
+// ImportStubs creates stub mappings in a single request.
+func (c *Client) ImportStubs(ctx context.Context, stubs []*StubRule, opts ImportOptions) error {
+	requestBody, err := json.Marshal(struct {
+		Mappings      []*StubRule   `json:"mappings"`
+		ImportOptions ImportOptions `json:"importOptions"`
+	}{
+		Mappings:      stubs,
+		ImportOptions: opts,
+	})
+	if err != nil {
+		return fmt.Errorf("import stubs: build error: %w", err)
+	}
+
+	if err := c.uploadBodyFiles(ctx, stubs...); err != nil {
+		return fmt.Errorf("import stubs: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/import", wiremockAdminMappingsURN), requestBody)
+	if err != nil {
+		return fmt.Errorf("import stubs: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("import stubs: %w", newAPIError(status, bodyBytes, stubErrors))
+	}
+
+	return nil
 }
 
-// StartRecording starts a recording.
-func (c *Client) StartRecording(targetBaseUrl string) error {
-	requestBody := fmt.Sprintf(`{"targetBaseUrl":"%s"}`, targetBaseUrl)
-	res, err := http.Post(
-		fmt.Sprintf("%s/%s/recordings/start", c.url, wiremockAdminURN),
-		"application/json",
-		strings.NewReader(requestBody),
-	)
+// GetSettings returns the global settings.
+func (c *Client) GetSettings() (*Settings, error) {
+	return c.GetSettingsCtx(context.Background())
+}
+
+// GetSettingsCtx returns the global settings.
+func (c *Client) GetSettingsCtx(ctx context.Context) (*Settings, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, wiremockAdminSettingsURN, nil)
 	if err != nil {
-		return fmt.Errorf("start recording error: %w", err)
+		return nil, fmt.Errorf("get settings: request error: %w", err)
 	}
 
-	defer res.Body.Close() //nolint:errcheck
-	if res.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return fmt.Errorf("read response error: %w", err)
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("get settings: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response struct {
+		Settings Settings `json:"settings"`
+	}
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("get settings: error unmarshalling response: %w", err)
+	}
+	return &response.Settings, nil
+}
+
+// UpdateSettings replaces the global settings.
+func (c *Client) UpdateSettings(settings Settings) error {
+	return c.UpdateSettingsCtx(context.Background(), settings)
+}
+
+// UpdateSettingsCtx replaces the global settings.
+func (c *Client) UpdateSettingsCtx(ctx context.Context, settings Settings) error {
+	requestBody, err := settings.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("update settings: build error: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, wiremockAdminSettingsURN, requestBody)
+	if err != nil {
+		return fmt.Errorf("update settings: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("update settings: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	return nil
+}
+
+// Health returns the health of WireMock.
+// Required wiremock >= 3.0.0
+func (c *Client) Health(ctx context.Context) (*Health, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/health", wiremockAdminURN), nil)
+	if err != nil {
+		return nil, fmt.Errorf("health: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("health: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response Health
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return nil, fmt.Errorf("health: error unmarshalling response: %w", err)
+	}
+	return &response, nil
+}
+
+// Version returns the version of WireMock.
+// Required wiremock >= 3.0.0
+func (c *Client) Version() (string, error) {
+	return c.VersionCtx(context.Background())
+}
+
+// VersionCtx returns the version of WireMock.
+// Required wiremock >= 3.0.0
+func (c *Client) VersionCtx(ctx context.Context) (string, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/version", wiremockAdminURN), nil)
+	if err != nil {
+		return "", fmt.Errorf("version: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return "", fmt.Errorf("version: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response struct {
+		Version string `json:"version"`
+	}
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return "", fmt.Errorf("version: error unmarshalling response: %w", err)
+	}
+	return response.Version, nil
+}
+
+// WaitUntilReady blocks until WireMock is healthy or ctx ends, the attempts are delayed by backoff.
+// WireMock 2.x, which has no health endpoint, is ready once it serves the admin API.
+// A nil backoff waits 100ms between the attempts.
+func (c *Client) WaitUntilReady(ctx context.Context, backoff Backoff) error {
+	if backoff == nil {
+		backoff = ConstantBackoff(defaultPollInterval)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.ready(ctx)
+		if err == nil {
+			return nil
+		}
+
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("wait until ready: %w: %w", ctx.Err(), err)
+		case <-timer.C:
 		}
+	}
+}
+
+func (c *Client) ready(ctx context.Context) error {
+	health, err := c.Health(ctx)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		_, err = c.GetAllStubsCtx(ctx, 1, 0)
+		return err
+	}
 
-		return fmt.Errorf("bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if err != nil {
+		return err
+	}
+
+	if health.Status != HealthStatusHealthy {
+		return fmt.Errorf("health: status %s: %s", health.Status, health.Message)
 	}
 
-	return err
+	return nil
 }
 
-// StopRecording stops a recording.
-func (c *Client) StopRecording() error {
-	res, err := http.Post(
-		fmt.Sprintf("%s/%s/recordings/stop", c.url, wiremockAdminURN),
-		"application/json",
-		nil,
-	)
+// Shutdown stops WireMock.
+func (c *Client) Shutdown() error {
+	return c.ShutdownCtx(context.Background())
+}
+
+// ShutdownCtx stops WireMock.
+func (c *Client) ShutdownCtx(ctx context.Context) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/shutdown", wiremockAdminURN), nil)
 	if err != nil {
-		return fmt.Errorf("stop recording error: %w", err)
+		return fmt.Errorf("shutdown: request error: %w", err)
 	}
 
-	defer res.Body.Close() //nolint:errcheck
-	if res.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(res.Body)
-		if err != nil {
-			return fmt.Errorf("read response error: %w", err)
+	if status != http.StatusOK {
+		return fmt.Errorf("shutdown: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	return nil
+}
+
+// PutFile uploads the body file, replacing the existing one.
+func (c *Client) PutFile(name string, content io.Reader) error {
+	return c.PutFileCtx(context.Background(), name, content)
+}
+
+// PutFileCtx uploads the body file, replacing the existing one.
+func (c *Client) PutFileCtx(ctx context.Context, name string, content io.Reader) error {
+	status, bodyBytes, err := c.doRawRequest(ctx, http.MethodPut, fileURN(name), content, "application/octet-stream")
+	if err != nil {
+		return fmt.Errorf("put file: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("put file: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	return nil
+}
+
+// GetFile returns the content of the body file.
+func (c *Client) GetFile(name string) ([]byte, error) {
+	return c.GetFileCtx(context.Background(), name)
+}
+
+// GetFileCtx returns the content of the body file.
+func (c *Client) GetFileCtx(ctx context.Context, name string) ([]byte, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, fileURN(name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get file: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("get file: %w", newAPIError(status, bodyBytes, fileErrors))
+	}
+
+	return bodyBytes, nil
+}
+
+// ListFiles returns the names of the body files.
+func (c *Client) ListFiles() ([]string, error) {
+	return c.ListFilesCtx(context.Background())
+}
+
+// ListFilesCtx returns the names of the body files.
+func (c *Client) ListFilesCtx(ctx context.Context) ([]string, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, wiremockAdminFilesURN, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list files: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("list files: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var files []string
+	err = json.Unmarshal(bodyBytes, &files)
+	if err != nil {
+		return nil, fmt.Errorf("list files: error unmarshalling response: %w", err)
+	}
+	return files, nil
+}
+
+// DeleteFile deletes the body file.
+func (c *Client) DeleteFile(name string) error {
+	return c.DeleteFileCtx(context.Background(), name)
+}
+
+// DeleteFileCtx deletes the body file.
+func (c *Client) DeleteFileCtx(ctx context.Context, name string) error {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodDelete, fileURN(name), nil)
+	if err != nil {
+		return fmt.Errorf("delete file: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return fmt.Errorf("delete file: %w", newAPIError(status, bodyBytes, fileErrors))
+	}
+
+	return nil
+}
+
+// uploadBodyFiles uploads the body files set by Response.WithUploadedBodyFile.
+func (c *Client) uploadBodyFiles(ctx context.Context, stubs ...*StubRule) error {
+	for _, stub := range stubs {
+		response, ok := stub.response.(Response)
+		if !ok || response.uploadedBodyFile == nil {
+			continue
+		}
+
+		if err := c.PutFileCtx(ctx, *response.bodyFileName, bytes.NewReader(response.uploadedBodyFile)); err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
+
+// fileURN returns the URN of the body file, the name may contain directories separated by slashes.
+func fileURN(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return fmt.Sprintf("%s/%s", wiremockAdminFilesURN, strings.Join(segments, "/"))
+}
+
+// StartRecording starts a recording.
+func (c *Client) StartRecording(targetBaseUrl string) error {
+	return c.StartRecordingCtx(context.Background(), targetBaseUrl)
+}
+
+// StartRecordingCtx starts a recording.
+func (c *Client) StartRecordingCtx(ctx context.Context, targetBaseUrl string) error {
+	return c.StartRecordingWithSpecCtx(ctx, NewRecordSpec().ForTarget(targetBaseUrl))
+}
+
+// StartRecordingWithSpec starts a recording configured by the spec.
+func (c *Client) StartRecordingWithSpec(spec *RecordSpec) error {
+	return c.StartRecordingWithSpecCtx(context.Background(), spec)
+}
+
+// StartRecordingWithSpecCtx starts a recording configured by the spec.
+func (c *Client) StartRecordingWithSpecCtx(ctx context.Context, spec *RecordSpec) error {
+	requestBody, err := spec.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("start recording: build error: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/recordings/start", wiremockAdminURN), requestBody)
+	if err != nil {
+		return fmt.Errorf("start recording: request error: %w", err)
+	}
 
-		return fmt.Errorf("bad response status: %d, response: %s", res.StatusCode, string(bodyBytes))
+	if status != http.StatusOK {
+		return fmt.Errorf("start recording: %w", newAPIError(status, bodyBytes, nil))
 	}
 
 	return nil
 }
+
+// StopRecording stops a recording and returns the recorded stubs.
+func (c *Client) StopRecording() ([]*StubRule, error) {
+	return c.StopRecordingCtx(context.Background())
+}
+
+// StopRecordingCtx stops a recording and returns the recorded stubs.
+func (c *Client) StopRecordingCtx(ctx context.Context) ([]*StubRule, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/recordings/stop", wiremockAdminURN), nil)
+	if err != nil {
+		return nil, fmt.Errorf("stop recording: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("stop recording: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	stubs, err := unmarshalRecordedStubs(bodyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("stop recording: error unmarshalling response: %w", err)
+	}
+	return stubs, nil
+}
+
+// GetRecordingStatus returns the status of the recording.
+func (c *Client) GetRecordingStatus() (RecordingStatus, error) {
+	return c.GetRecordingStatusCtx(context.Background())
+}
+
+// GetRecordingStatusCtx returns the status of the recording.
+func (c *Client) GetRecordingStatusCtx(ctx context.Context) (RecordingStatus, error) {
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/recordings/status", wiremockAdminURN), nil)
+	if err != nil {
+		return "", fmt.Errorf("get recording status: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return "", fmt.Errorf("get recording status: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	var response struct {
+		Status RecordingStatus `json:"status"`
+	}
+	err = json.Unmarshal(bodyBytes, &response)
+	if err != nil {
+		return "", fmt.Errorf("get recording status: error unmarshalling response: %w", err)
+	}
+	return response.Status, nil
+}
+
+// SnapshotRecord generates stubs from the requests of the journal proxied by WireMock, nil spec uses the defaults.
+func (c *Client) SnapshotRecord(spec *RecordSpec) ([]*StubRule, error) {
+	return c.SnapshotRecordCtx(context.Background(), spec)
+}
+
+// SnapshotRecordCtx generates stubs from the requests of the journal proxied by WireMock, nil spec uses the defaults.
+func (c *Client) SnapshotRecordCtx(ctx context.Context, spec *RecordSpec) ([]*StubRule, error) {
+	if spec == nil {
+		spec = NewRecordSpec()
+	}
+
+	requestBody, err := spec.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("snapshot record: build error: %w", err)
+	}
+
+	status, bodyBytes, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/recordings/snapshot", wiremockAdminURN), requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot record: request error: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("snapshot record: %w", newAPIError(status, bodyBytes, nil))
+	}
+
+	stubs, err := unmarshalRecordedStubs(bodyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot record: error unmarshalling response: %w", err)
+	}
+	return stubs, nil
+}
+
+func unmarshalRecordedStubs(bodyBytes []byte) ([]*StubRule, error) {
+	var response struct {
+		Mappings []*StubRule `json:"mappings"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return nil, err
+	}
+	return response.Mappings, nil
+}
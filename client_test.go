@@ -2,10 +2,20 @@ package wiremock_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	tc "github.com/testcontainers/testcontainers-go"
@@ -109,6 +119,9 @@ func TestClient_GetRequestsByID(t *testing.T) {
 		if !strings.Contains(err.Error(), "bad response status: 404") {
 			t.Errorf("expected error message to contain 'bad response status: 404', got %s", err.Error())
 		}
+		if !errors.Is(err, wiremock.ErrRequestNotFound) {
+			t.Errorf("expected ErrRequestNotFound, got %v", err)
+		}
 
 		assertNil(t, request)
 	})
@@ -372,6 +385,990 @@ func TestClient_DeleteRequestsByCriteria(t *testing.T) {
 	})
 }
 
+func TestClient_GetAllStubs(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	for _, path := range []string{"/test-1", "/test-2", "/test-3"} {
+		err = svc.client.StubFor(wiremock.Get(wiremock.URLPathEqualTo(path)).WillReturnResponse(wiremock.OK()))
+		requireNoError(t, err)
+	}
+
+	t.Run("all stubs", func(t *testing.T) {
+		stubs, err := svc.client.GetAllStubs(0, 0)
+		requireNoError(t, err)
+
+		assertEqual(t, int64(3), stubs.Meta.Total)
+		assertEqual(t, 3, len(stubs.Mappings))
+	})
+
+	t.Run("paged stubs", func(t *testing.T) {
+		stubs, err := svc.client.GetAllStubs(2, 2)
+		requireNoError(t, err)
+
+		assertEqual(t, int64(3), stubs.Meta.Total)
+		assertEqual(t, 1, len(stubs.Mappings))
+	})
+}
+
+func TestClient_GetStubByID(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	t.Run("invalid stub id", func(t *testing.T) {
+		err := svc.Reset()
+		requireNoError(t, err)
+
+		stub, err := svc.client.GetStubByID(uuid.NewString())
+		if !errors.Is(err, wiremock.ErrStubNotFound) {
+			t.Errorf("expected ErrStubNotFound, got %v", err)
+		}
+
+		assertNil(t, stub)
+	})
+
+	t.Run("valid stub id", func(t *testing.T) {
+		err := svc.Reset()
+		requireNoError(t, err)
+
+		stubRule := wiremock.Post(wiremock.URLPathEqualTo("/test")).
+			WithHeader("x-session", wiremock.Matching("^\\S+$")).
+			WithBodyPattern(wiremock.EqualToJson(`{"meta": "information"}`)).
+			WillReturnResponse(
+				wiremock.NewResponse().
+					WithStatus(http.StatusCreated).
+					WithBody("created").
+					WithFixedDelay(time.Second),
+			).
+			InScenario("Scenario").
+			WhenScenarioStateIs(wiremock.ScenarioStateStarted).
+			WillSetStateTo("Created")
+		err = svc.client.StubFor(stubRule)
+		requireNoError(t, err)
+
+		stub, err := svc.client.GetStubByID(stubRule.UUID())
+		requireNoError(t, err)
+
+		assertEqual(t, stubRule.UUID(), stub.ID)
+		assertEqual(t, "POST", stub.Request.Method)
+		assertEqual(t, "/test", stub.Request.URLPath)
+		assertEqual(t, "^\\S+$", stub.Request.Headers["x-session"]["matches"])
+		assertEqual(t, 1, len(stub.Request.BodyPatterns))
+		assertEqual(t, int64(http.StatusCreated), stub.Response.Status)
+		assertEqual(t, "created", stub.Response.Body)
+		assertEqual(t, int64(1000), stub.Response.DelayDistribution.Milliseconds)
+		assertEqual(t, "Scenario", stub.ScenarioName)
+		assertEqual(t, wiremock.ScenarioStateStarted, stub.RequiredScenarioState)
+		assertEqual(t, "Created", stub.NewScenarioState)
+	})
+}
+
+func TestClient_EditStub(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	t.Run("not existing stub", func(t *testing.T) {
+		err := svc.Reset()
+		requireNoError(t, err)
+
+		_, err = svc.client.EditStub(wiremock.Get(wiremock.URLPathEqualTo("/test")))
+		if !errors.Is(err, wiremock.ErrStubNotFound) {
+			t.Errorf("expected ErrStubNotFound, got %v", err)
+		}
+	})
+
+	t.Run("existing stub", func(t *testing.T) {
+		err := svc.Reset()
+		requireNoError(t, err)
+
+		stubRule := wiremock.Get(wiremock.URLPathEqualTo("/test")).WillReturnResponse(wiremock.OK())
+		err = svc.client.StubFor(stubRule)
+		requireNoError(t, err)
+
+		stub, err := svc.client.EditStub(stubRule.WillReturnResponse(wiremock.NewResponse().WithStatus(http.StatusTeapot)))
+		requireNoError(t, err)
+		assertEqual(t, int64(http.StatusTeapot), stub.Response.Status)
+
+		res, err := http.Get(svc.baseURL + "/test")
+		requireNoError(t, err)
+		assertEqual(t, http.StatusTeapot, res.StatusCode)
+	})
+}
+
+func TestClient_ImportStubs(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	t.Run("import stubs", func(t *testing.T) {
+		err := svc.Reset()
+		requireNoError(t, err)
+
+		err = svc.client.ImportStubs(ctx, []*wiremock.StubRule{
+			wiremock.Get(wiremock.URLPathEqualTo("/test-1")).WillReturnResponse(wiremock.OK()),
+			wiremock.Get(wiremock.URLPathEqualTo("/test-2")).WillReturnResponse(wiremock.OK()),
+		}, wiremock.ImportOptions{})
+		requireNoError(t, err)
+
+		stubs, err := svc.client.GetAllStubs(0, 0)
+		requireNoError(t, err)
+		assertEqual(t, 2, len(stubs.Mappings))
+	})
+
+	t.Run("ignore duplicates", func(t *testing.T) {
+		err := svc.Reset()
+		requireNoError(t, err)
+
+		stubRule := wiremock.Get(wiremock.URLPathEqualTo("/test")).WillReturnResponse(wiremock.OK())
+		err = svc.client.StubFor(stubRule)
+		requireNoError(t, err)
+
+		err = svc.client.ImportStubs(ctx, []*wiremock.StubRule{
+			stubRule.WillReturnResponse(wiremock.NewResponse().WithStatus(http.StatusTeapot)),
+		}, wiremock.ImportOptions{DuplicatePolicy: wiremock.DuplicatePolicyIgnore})
+		requireNoError(t, err)
+
+		stub, err := svc.client.GetStubByID(stubRule.UUID())
+		requireNoError(t, err)
+		assertEqual(t, int64(http.StatusOK), stub.Response.Status)
+	})
+
+	t.Run("delete all not in import", func(t *testing.T) {
+		err := svc.Reset()
+		requireNoError(t, err)
+
+		err = svc.client.StubFor(wiremock.Get(wiremock.URLPathEqualTo("/old")).WillReturnResponse(wiremock.OK()))
+		requireNoError(t, err)
+
+		imported := wiremock.Get(wiremock.URLPathEqualTo("/new")).WillReturnResponse(wiremock.OK())
+		err = svc.client.ImportStubs(ctx, []*wiremock.StubRule{imported}, wiremock.ImportOptions{
+			DuplicatePolicy:      wiremock.DuplicatePolicyOverwrite,
+			DeleteAllNotInImport: true,
+		})
+		requireNoError(t, err)
+
+		stubs, err := svc.client.GetAllStubs(0, 0)
+		requireNoError(t, err)
+		assertEqual(t, 1, len(stubs.Mappings))
+		assertEqual(t, imported.UUID(), stubs.Mappings[0].ID)
+	})
+}
+
+func TestClient_StubsByMetadata(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	ownStub := wiremock.Get(wiremock.URLPathEqualTo("/own")).
+		WithMetadata(map[string]interface{}{"suite": "metadata", "own": true}).
+		WillReturnResponse(wiremock.OK())
+	err = svc.client.StubFor(ownStub)
+	requireNoError(t, err)
+
+	otherStub := wiremock.Get(wiremock.URLPathEqualTo("/other")).
+		WithMetadata(map[string]interface{}{"suite": "metadata"}).
+		WillReturnResponse(wiremock.OK())
+	err = svc.client.StubFor(otherStub)
+	requireNoError(t, err)
+
+	matcher := wiremock.MatchingJsonPath("$.own")
+
+	stubs, err := svc.client.FindStubsByMetadata(matcher)
+	requireNoError(t, err)
+	assertEqual(t, 1, len(stubs.Mappings))
+	assertEqual(t, ownStub.UUID(), stubs.Mappings[0].ID)
+	assertEqual(t, map[string]interface{}{"suite": "metadata", "own": true}, stubs.Mappings[0].Metadata)
+
+	err = svc.client.RemoveStubsByMetadata(matcher)
+	requireNoError(t, err)
+
+	all, err := svc.client.GetAllStubs(0, 0)
+	requireNoError(t, err)
+	assertEqual(t, 1, len(all.Mappings))
+	assertEqual(t, otherStub.UUID(), all.Mappings[0].ID)
+}
+
+func TestClient_SaveMappings(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	id := uuid.NewString()
+	err = svc.client.StubFor(wiremock.Get(wiremock.URLPathEqualTo("/persistent")).
+		WithID(id).
+		WithName("Persistent stub").
+		Persistent().
+		WillReturnResponse(wiremock.OK()))
+	requireNoError(t, err)
+
+	stub, err := svc.client.GetStubByID(id)
+	requireNoError(t, err)
+	assertEqual(t, "Persistent stub", stub.Name)
+	assertEqual(t, true, stub.Persistent)
+
+	err = svc.client.SaveMappings()
+	requireNoError(t, err)
+
+	err = svc.client.DeleteStubByID(id)
+	requireNoError(t, err)
+}
+
+func TestClient_Scenarios(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	started := wiremock.Get(wiremock.URLPathEqualTo("/state")).
+		InScenario("Workflow").
+		WhenScenarioStateIs(wiremock.ScenarioStateStarted).
+		WillSetStateTo("Step 2").
+		WillReturnResponse(wiremock.NewResponse().WithBody("step 1"))
+	err = svc.client.StubFor(started)
+	requireNoError(t, err)
+
+	step2 := wiremock.Get(wiremock.URLPathEqualTo("/state")).
+		InScenario("Workflow").
+		WhenScenarioStateIs("Step 2").
+		WillSetStateTo("Done").
+		WillReturnResponse(wiremock.NewResponse().WithBody("step 2"))
+	err = svc.client.StubFor(step2)
+	requireNoError(t, err)
+
+	t.Run("get all scenarios", func(t *testing.T) {
+		scenarios, err := svc.client.GetAllScenarios()
+		requireNoError(t, err)
+
+		assertEqual(t, 1, len(scenarios.Scenarios))
+		scenario := scenarios.Scenarios[0]
+		assertEqual(t, "Workflow", scenario.Name)
+		assertEqual(t, wiremock.ScenarioStateStarted, scenario.State)
+		assertEqual(t, 2, len(scenario.MappingIDs()))
+	})
+
+	t.Run("set scenario state", func(t *testing.T) {
+		err := svc.client.SetScenarioState("Workflow", "Step 2")
+		requireNoError(t, err)
+
+		scenarios, err := svc.client.GetAllScenarios()
+		requireNoError(t, err)
+		assertEqual(t, "Step 2", scenarios.Scenarios[0].State)
+	})
+
+	t.Run("reset scenario", func(t *testing.T) {
+		err := svc.client.ResetScenario("Workflow")
+		requireNoError(t, err)
+
+		scenarios, err := svc.client.GetAllScenarios()
+		requireNoError(t, err)
+		assertEqual(t, wiremock.ScenarioStateStarted, scenarios.Scenarios[0].State)
+	})
+
+	t.Run("not existing scenario", func(t *testing.T) {
+		err := svc.client.SetScenarioState("Missing", "Step 2")
+		if !errors.Is(err, wiremock.ErrScenarioNotFound) {
+			t.Errorf("expected ErrScenarioNotFound, got %v", err)
+		}
+	})
+}
+
+func TestClient_StubForScenario(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	scenario := wiremock.NewScenario("Sequence").Sequence(
+		wiremock.Get(wiremock.URLPathEqualTo("/sequence")).WillReturnResponse(wiremock.NewResponse().WithBody("first")),
+		wiremock.Get(wiremock.URLPathEqualTo("/sequence")).WillReturnResponse(wiremock.NewResponse().WithBody("second")),
+	)
+
+	err = svc.client.StubForScenario(scenario)
+	requireNoError(t, err)
+
+	for _, expected := range []string{"first", "second", "second"} {
+		res, err := http.Get(svc.baseURL + "/sequence")
+		requireNoError(t, err)
+
+		body, err := io.ReadAll(res.Body)
+		requireNoError(t, err)
+		_ = res.Body.Close()
+
+		assertEqual(t, expected, string(body))
+	}
+
+	err = svc.client.DeleteScenario(scenario)
+	requireNoError(t, err)
+
+	stubs, err := svc.client.GetAllStubs(0, 0)
+	requireNoError(t, err)
+	assertEqual(t, 0, len(stubs.Mappings))
+}
+
+func TestClient_FindNearMisses(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	stub := wiremock.Get(wiremock.URLPathEqualTo("/near-miss")).
+		WithHeader("x-session", wiremock.EqualTo("session")).
+		WillReturnResponse(wiremock.OK())
+	err = svc.client.StubFor(stub)
+	requireNoError(t, err)
+
+	_, err = http.Get(svc.baseURL + "/near-miss")
+	requireNoError(t, err)
+
+	t.Run("for unmatched", func(t *testing.T) {
+		nearMisses, err := svc.client.FindNearMissesForUnmatched()
+		requireNoError(t, err)
+
+		assertEqual(t, 1, len(nearMisses.NearMisses))
+		assertEqual(t, stub.UUID(), nearMisses.NearMisses[0].StubMapping.ID)
+		if !strings.Contains(nearMisses.NearMisses[0].Format(), "header x-session") {
+			t.Errorf("expected header diff, got:\n%s", nearMisses.NearMisses[0].Format())
+		}
+	})
+
+	t.Run("for request", func(t *testing.T) {
+		unmatched, err := svc.client.FindUnmatchedRequests()
+		requireNoError(t, err)
+
+		nearMisses, err := svc.client.FindNearMissesForRequest(unmatched.Requests[0])
+		requireNoError(t, err)
+
+		assertEqual(t, stub.UUID(), nearMisses.NearMisses[0].StubMapping.ID)
+	})
+
+	t.Run("for pattern", func(t *testing.T) {
+		nearMisses, err := svc.client.FindNearMissesForPattern(stub.Request())
+		requireNoError(t, err)
+
+		assertEqual(t, 1, len(nearMisses.NearMisses))
+		assertEqual(t, "/near-miss", nearMisses.NearMisses[0].Request.URL)
+	})
+}
+
+func TestClient_VerifyThat(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	err = svc.client.StubFor(wiremock.Get(wiremock.URLPathEqualTo("/verify")).
+		WillReturnResponse(wiremock.OK()))
+	requireNoError(t, err)
+
+	_, err = http.Get(svc.baseURL + "/verify")
+	requireNoError(t, err)
+	_, err = http.Get(svc.baseURL + "/verify")
+	requireNoError(t, err)
+
+	request := wiremock.NewRequest("GET", wiremock.URLPathEqualTo("/verify"))
+
+	requireNoError(t, svc.client.VerifyThat(request, wiremock.Exactly(2)))
+	requireNoError(t, svc.client.VerifyThat(request, wiremock.AtLeast(1)))
+	requireNoError(t, svc.client.VerifyThat(request, wiremock.Between(1, 2)))
+	requireNoError(t, svc.client.VerifyThat(wiremock.NewRequest("POST", wiremock.URLPathEqualTo("/verify")), wiremock.Never()))
+
+	err = svc.client.VerifyThat(request, wiremock.AtMost(1))
+
+	var verificationErr *wiremock.VerificationError
+	if !errors.As(err, &verificationErr) {
+		t.Fatalf("expected VerificationError, got %v", err)
+	}
+	assertEqual(t, int64(2), verificationErr.Count)
+	assertEqual(t, 2, len(verificationErr.Requests))
+	if !strings.Contains(err.Error(), "expected at most 1 requests matching GET urlPath /verify, received 2") {
+		t.Errorf("unexpected error message:\n%s", err.Error())
+	}
+}
+
+func TestClient_GetRequests(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	err = svc.client.StubFor(wiremock.Get(wiremock.URLPathEqualTo("/matched")).
+		WillReturnResponse(wiremock.OK()))
+	requireNoError(t, err)
+
+	_, err = http.Get(svc.baseURL + "/matched")
+	requireNoError(t, err)
+
+	since := time.Now().Add(-time.Second)
+	_, err = http.Get(svc.baseURL + "/matched")
+	requireNoError(t, err)
+	_, err = http.Get(svc.baseURL + "/unmatched")
+	requireNoError(t, err)
+
+	t.Run("limit", func(t *testing.T) {
+		requests, err := svc.client.GetRequests(ctx, wiremock.RequestsQuery{Limit: 2})
+		requireNoError(t, err)
+
+		assertEqual(t, 2, len(requests.Requests))
+		assertEqual(t, "/unmatched", requests.Requests[0].Request.URL)
+	})
+
+	t.Run("since", func(t *testing.T) {
+		requests, err := svc.client.GetRequests(ctx, wiremock.RequestsQuery{Since: since})
+		requireNoError(t, err)
+
+		for _, request := range requests.Requests {
+			if request.Request.LoggedDate.Before(since) {
+				t.Errorf("expected request logged after %v, got %v", since, request.Request.LoggedDate)
+			}
+		}
+	})
+
+	t.Run("unmatched", func(t *testing.T) {
+		var urls []string
+		for request, err := range svc.client.Requests(ctx, wiremock.RequestsQuery{Unmatched: true}) {
+			requireNoError(t, err)
+			urls = append(urls, request.Request.URL)
+		}
+
+		assertEqual(t, []string{"/unmatched"}, urls)
+	})
+}
+
+func TestClient_SnapshotRecord(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.Reset()
+	requireNoError(t, err)
+
+	err = svc.client.StubFor(wiremock.Get(wiremock.URLPathEqualTo("/recorded")).
+		WillReturnResponse(wiremock.NewResponse().WithBody("recorded")))
+	requireNoError(t, err)
+
+	_, err = http.Get(svc.baseURL + "/recorded")
+	requireNoError(t, err)
+
+	status, err := svc.client.GetRecordingStatus()
+	requireNoError(t, err)
+	if status == wiremock.RecordingStatusRecording {
+		t.Errorf("expected recording not to be started, got %s", status)
+	}
+
+	stubs, err := svc.client.SnapshotRecord(wiremock.NewRecordSpec().
+		OnlyRequestsMatching(wiremock.NewRequest("GET", wiremock.URLPathEqualTo("/recorded"))).
+		AllowNonProxied(true).
+		MakeStubsPersistent(false))
+	requireNoError(t, err)
+
+	assertEqual(t, 1, len(stubs))
+	rawStub, err := stubs[0].MarshalJSON()
+	requireNoError(t, err)
+	if !strings.Contains(string(rawStub), `"/recorded"`) {
+		t.Errorf("expected stub of /recorded, got %s", rawStub)
+	}
+}
+
+func TestClient_Settings(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.client.UpdateSettings(wiremock.Settings{
+		FixedDelay: 10 * time.Millisecond,
+		Extended:   map[string]any{"owner": "go-wiremock"},
+	})
+	requireNoError(t, err)
+	defer func() {
+		requireNoError(t, svc.client.UpdateSettings(wiremock.Settings{}))
+	}()
+
+	settings, err := svc.client.GetSettings()
+	requireNoError(t, err)
+
+	assertEqual(t, 10*time.Millisecond, settings.FixedDelay)
+	assertEqual(t, "go-wiremock", settings.Extended["owner"])
+}
+
+func TestClient_Health(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	err := svc.client.WaitUntilReady(ctx, wiremock.ExponentialBackoff(10*time.Millisecond, time.Second))
+	requireNoError(t, err)
+
+	health, err := svc.client.Health(ctx)
+	requireNoError(t, err)
+	assertEqual(t, wiremock.HealthStatusHealthy, health.Status)
+
+	version, err := svc.client.Version()
+	requireNoError(t, err)
+	assertEqual(t, version, health.Version)
+}
+
+func TestClient_UploadedBodyFile(t *testing.T) {
+	ctx := context.Background()
+	svc := getWiremockTestService(ctx, t)
+
+	stub := wiremock.Get(wiremock.URLPathEqualTo("/uploaded")).
+		WillReturnResponse(wiremock.OK().WithUploadedBodyFile("uploaded/body.txt", []byte("uploaded body")))
+	requireNoError(t, svc.client.StubFor(stub))
+	defer func() {
+		requireNoError(t, svc.client.DeleteFile("uploaded/body.txt"))
+	}()
+
+	names, err := svc.client.ListFiles()
+	requireNoError(t, err)
+	assertEqual(t, true, slices.Contains(names, "uploaded/body.txt"))
+
+	resp, err := http.Get(svc.baseURL + "/uploaded")
+	requireNoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	requireNoError(t, err)
+	assertEqual(t, "uploaded body", string(body))
+}
+
+func TestClient_Options(t *testing.T) {
+	t.Run("base headers", func(t *testing.T) {
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+		}))
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL, wiremock.WithHeader("Authorization", "Bearer admin"))
+		err := client.Reset()
+		requireNoError(t, err)
+
+		assertEqual(t, "Bearer admin", authorization)
+	})
+
+	t.Run("nil http client", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL, wiremock.WithHTTPClient(nil))
+		requireNoError(t, client.Reset())
+	})
+
+	t.Run("default timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL, wiremock.WithTimeout(50*time.Millisecond))
+		err := client.Reset()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded error, got %v", err)
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := wiremock.NewClient(server.URL, wiremock.WithHTTPClient(server.Client()))
+		_, err := client.GetAllRequestsCtx(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context canceled error, got %v", err)
+		}
+	})
+}
+
+func TestClient_VerifyEventually(t *testing.T) {
+	request := wiremock.NewRequest("POST", wiremock.URLPathEqualTo("/events"))
+
+	newServer := func(counts ...int) *httptest.Server {
+		var polls int
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/__admin/requests/count":
+				count := counts[min(polls, len(counts)-1)]
+				polls++
+				if count < 0 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"count":%d}`, count)
+			case "/__admin/near-misses/request-pattern":
+				_, _ = w.Write([]byte(`{"nearMisses":[]}`))
+			case "/__admin/requests":
+				if r.URL.Query().Get("limit") == "" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				_, _ = w.Write([]byte(`{"requests":[{"id":"1","request":{"method":"POST","url":"/events"}}]}`))
+			}
+		}))
+	}
+
+	t.Run("condition holds", func(t *testing.T) {
+		server := newServer(0, 1, 2)
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		requireNoError(t, client.VerifyEventually(ctx, request, wiremock.Exactly(2), time.Millisecond))
+		requireNoError(t, client.WaitForRequests(ctx, request, 2))
+	})
+
+	t.Run("transient errors", func(t *testing.T) {
+		server := newServer(-1, -1, 1)
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		requireNoError(t, client.VerifyEventually(ctx, request, wiremock.Exactly(1), time.Millisecond))
+	})
+
+	t.Run("non-positive poll interval", func(t *testing.T) {
+		server := newServer(0, 1)
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		requireNoError(t, client.VerifyEventually(ctx, request, wiremock.Exactly(1), 0))
+	})
+
+	t.Run("timeout after errors", func(t *testing.T) {
+		server := newServer(-1)
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := client.VerifyEventually(ctx, request, wiremock.Exactly(1), time.Millisecond)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded error, got %v", err)
+		}
+
+		var apiErr *wiremock.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("expected last poll error, got %v", err)
+		}
+
+		var verificationErr *wiremock.VerificationError
+		if errors.As(err, &verificationErr) {
+			t.Errorf("expected no verification error without a successful poll, got %v", verificationErr)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := newServer(1)
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := client.WaitForRequests(ctx, request, 2)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded error, got %v", err)
+		}
+
+		var verificationErr *wiremock.VerificationError
+		if !errors.As(err, &verificationErr) {
+			t.Fatalf("expected VerificationError, got %v", err)
+		}
+		assertEqual(t, int64(1), verificationErr.Count)
+		assertEqual(t, 1, len(verificationErr.Requests))
+	})
+}
+
+func TestClient_Requests(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"meta":{"total":3},"requests":[{"id":"1","request":{"url":"/1"}},{"id":"2","request":{"url":"/2"}},{"id":"3","request":{"url":"/3"}}],"requestJournalDisabled":false}`))
+	}))
+	defer server.Close()
+
+	client := wiremock.NewClient(server.URL)
+	since := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.FixedZone("UTC+1", 3600))
+
+	t.Run("query", func(t *testing.T) {
+		response, err := client.GetRequests(context.Background(), wiremock.RequestsQuery{Since: since, Limit: 10, Unmatched: true})
+		requireNoError(t, err)
+
+		assertEqual(t, "2024-01-02T02:04:05.006Z", query.Get("since"))
+		assertEqual(t, "10", query.Get("limit"))
+		assertEqual(t, "true", query.Get("unmatched"))
+		assertEqual(t, 3, len(response.Requests))
+	})
+
+	t.Run("iterator", func(t *testing.T) {
+		var ids []string
+		for request, err := range client.Requests(context.Background(), wiremock.RequestsQuery{}) {
+			requireNoError(t, err)
+			ids = append(ids, request.ID)
+			if len(ids) == 2 {
+				break
+			}
+		}
+
+		assertEqual(t, []string{"1", "2"}, ids)
+		assertEqual(t, url.Values{}, query)
+	})
+
+	t.Run("iterator error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		var errs []error
+		for _, err := range wiremock.NewClient(server.URL).Requests(context.Background(), wiremock.RequestsQuery{}) {
+			errs = append(errs, err)
+		}
+
+		assertEqual(t, 1, len(errs))
+		var apiErr *wiremock.APIError
+		if !errors.As(errs[0], &apiErr) {
+			t.Fatalf("expected *APIError, got %v", errs[0])
+		}
+	})
+}
+
+func TestClient_Recording(t *testing.T) {
+	var startBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/__admin/recordings/start":
+			startBody, _ = io.ReadAll(r.Body)
+		case "/__admin/recordings/status":
+			_, _ = w.Write([]byte(`{"status":"Recording"}`))
+		case "/__admin/recordings/stop":
+			_, _ = w.Write([]byte(`{"mappings":[{"id":"093f1027-e5e0-4921-9e6d-e57b2f3e4f5e","request":{"url":"/recorded","method":"GET"},"response":{"status":200,"body":"recorded"}}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := wiremock.NewClient(server.URL)
+
+	err := client.StartRecording(`http://example.com/"quoted"`)
+	requireNoError(t, err)
+	assertEqual(t, `{"targetBaseUrl":"http://example.com/\"quoted\""}`, string(startBody))
+
+	status, err := client.GetRecordingStatus()
+	requireNoError(t, err)
+	assertEqual(t, wiremock.RecordingStatusRecording, status)
+
+	stubs, err := client.StopRecording()
+	requireNoError(t, err)
+	assertEqual(t, 1, len(stubs))
+	assertEqual(t, "093f1027-e5e0-4921-9e6d-e57b2f3e4f5e", stubs[0].UUID())
+}
+
+func TestClient_WaitUntilReady(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		var attempts int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"status":"unhealthy","message":"Wiremock is not ok"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"healthy","message":"Wiremock is ok","version":"3.9.1","uptimeInSeconds":14,"timestamp":"2024-07-03T13:16:06.172362Z"}`))
+		}))
+		defer server.Close()
+
+		client := wiremock.NewClient(server.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		err := client.WaitUntilReady(ctx, wiremock.ConstantBackoff(time.Millisecond))
+		requireNoError(t, err)
+		assertEqual(t, 3, attempts)
+
+		health, err := client.Health(ctx)
+		requireNoError(t, err)
+		assertEqual(t, "3.9.1", health.Version)
+		assertEqual(t, 14*time.Second, health.Uptime)
+	})
+
+	t.Run("without health endpoint", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/__admin/health" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"mappings":[],"meta":{"total":0}}`))
+		}))
+		defer server.Close()
+
+		err := wiremock.NewClient(server.URL).WaitUntilReady(context.Background(), nil)
+		requireNoError(t, err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := wiremock.NewClient(server.URL).WaitUntilReady(ctx, wiremock.ConstantBackoff(time.Millisecond))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected deadline exceeded error, got %v", err)
+		}
+	})
+}
+
+func TestClient_Files(t *testing.T) {
+	var mu sync.Mutex
+	files := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		name := strings.TrimPrefix(r.URL.Path, "/__admin/files/")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/__admin/files":
+			_ = json.NewEncoder(w).Encode(slices.Sorted(maps.Keys(files)))
+		case r.Method == http.MethodPut:
+			if r.Header.Get("Content-Type") != "application/octet-stream" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			files[name], _ = io.ReadAll(r.Body)
+		case r.Method == http.MethodGet:
+			content, ok := files[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(content)
+		case r.Method == http.MethodDelete:
+			if _, ok := files[name]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(files, name)
+		case r.Method == http.MethodPost && r.URL.Path == "/__admin/mappings":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := wiremock.NewClient(server.URL)
+
+	t.Run("put, get, list and delete", func(t *testing.T) {
+		requireNoError(t, client.PutFile("docs/report 1.json", strings.NewReader(`{"id":1}`)))
+
+		content, err := client.GetFile("docs/report 1.json")
+		requireNoError(t, err)
+		assertEqual(t, `{"id":1}`, string(content))
+
+		names, err := client.ListFiles()
+		requireNoError(t, err)
+		assertEqual(t, "docs/report 1.json", strings.Join(names, ","))
+
+		requireNoError(t, client.DeleteFile("docs/report 1.json"))
+
+		_, err = client.GetFile("docs/report 1.json")
+		if !errors.Is(err, wiremock.ErrFileNotFound) {
+			t.Fatalf("expected ErrFileNotFound, got %v", err)
+		}
+
+		err = client.DeleteFile("docs/report 1.json")
+		if !errors.Is(err, wiremock.ErrFileNotFound) {
+			t.Fatalf("expected ErrFileNotFound, got %v", err)
+		}
+	})
+
+	t.Run("uploaded body file", func(t *testing.T) {
+		stub := wiremock.Get(wiremock.URLPathEqualTo("/report")).
+			WillReturnResponse(wiremock.OK().WithUploadedBodyFile("report.csv", []byte("id,name\n1,report")))
+		requireNoError(t, client.StubFor(stub))
+
+		content, err := client.GetFile("report.csv")
+		requireNoError(t, err)
+		assertEqual(t, "id,name\n1,report", string(content))
+	})
+}
+
+func TestClient_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":[{"code":10,"source":{"pointer":"/request"},"title":"Error parsing JSON","detail":"Unrecognized field"}]}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := wiremock.NewClient(server.URL)
+
+	t.Run("invalid stub", func(t *testing.T) {
+		err := client.StubFor(wiremock.Get(wiremock.URLPathEqualTo("/example")))
+		if !errors.Is(err, wiremock.ErrInvalidStub) {
+			t.Fatalf("expected ErrInvalidStub, got %v", err)
+		}
+
+		var apiErr *wiremock.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected *APIError, got %T", err)
+		}
+
+		assertEqual(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+		assertEqual(t, 1, len(apiErr.Errors))
+		assertEqual(t, "Error parsing JSON", apiErr.Errors[0].Title)
+		assertEqual(t, "Unrecognized field", apiErr.Errors[0].Detail)
+		assertEqual(t, "/request", apiErr.Errors[0].Source.Pointer)
+	})
+
+	t.Run("stub not found", func(t *testing.T) {
+		err := client.DeleteStubByID(uuid.NewString())
+		if !errors.Is(err, wiremock.ErrStubNotFound) {
+			t.Fatalf("expected ErrStubNotFound, got %v", err)
+		}
+		if errors.Is(err, wiremock.ErrInvalidStub) {
+			t.Errorf("expected not to match ErrInvalidStub, got %v", err)
+		}
+		if !strings.HasPrefix(err.Error(), "delete stub by id: ") {
+			t.Errorf("expected operation prefix, got %v", err)
+		}
+	})
+
+	t.Run("request not found", func(t *testing.T) {
+		err := client.DeleteRequestByID(uuid.NewString())
+		if !errors.Is(err, wiremock.ErrRequestNotFound) {
+			t.Fatalf("expected ErrRequestNotFound, got %v", err)
+		}
+	})
+}
+
 func requireNoError(t *testing.T, err error) {
 	if err != nil {
 		t.Fatal(err)
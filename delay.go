@@ -2,6 +2,7 @@ package wiremock
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -60,6 +61,48 @@ func (d chunkedDribbleDelay) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonMap)
 }
 
+// UnmarshalJSON parses the JSON encoding of the delay.
+func (d *chunkedDribbleDelay) UnmarshalJSON(data []byte) error {
+	var jsonDelay struct {
+		NumberOfChunks int64 `json:"numberOfChunks"`
+		TotalDuration  int64 `json:"totalDuration"`
+	}
+	if err := json.Unmarshal(data, &jsonDelay); err != nil {
+		return err
+	}
+
+	d.numberOfChunks = jsonDelay.NumberOfChunks
+	d.totalDuration = jsonDelay.TotalDuration
+
+	return nil
+}
+
+// unmarshalDelay parses the JSON encoding of a delay distribution.
+func unmarshalDelay(data []byte) (DelayInterface, error) {
+	var jsonDelay struct {
+		Type         string  `json:"type"`
+		Milliseconds int64   `json:"milliseconds"`
+		Median       int64   `json:"median"`
+		Sigma        float64 `json:"sigma"`
+		Lower        int64   `json:"lower"`
+		Upper        int64   `json:"upper"`
+	}
+	if err := json.Unmarshal(data, &jsonDelay); err != nil {
+		return nil, err
+	}
+
+	switch jsonDelay.Type {
+	case "fixed":
+		return fixedDelay{milliseconds: jsonDelay.Milliseconds}, nil
+	case "lognormal":
+		return logNormalRandomDelay{median: jsonDelay.Median, sigma: jsonDelay.Sigma}, nil
+	case "uniform":
+		return uniformRandomDelay{lower: jsonDelay.Lower, upper: jsonDelay.Upper}, nil
+	default:
+		return nil, fmt.Errorf("unknown delay type: %q", jsonDelay.Type)
+	}
+}
+
 func NewLogNormalRandomDelay(median time.Duration, sigma float64) DelayInterface {
 	return logNormalRandomDelay{
 		median: median.Milliseconds(),
@@ -0,0 +1,64 @@
+package wiremock
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// HealthStatusHealthy is the status of a healthy WireMock.
+const HealthStatusHealthy = "healthy"
+
+// Health is the health of WireMock.
+// Required wiremock >= 3.0.0
+type Health struct {
+	Status    string
+	Message   string
+	Version   string
+	Uptime    time.Duration
+	Timestamp time.Time
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (h *Health) UnmarshalJSON(data []byte) error {
+	var jsonHealth struct {
+		Status          string    `json:"status"`
+		Message         string    `json:"message"`
+		Version         string    `json:"version"`
+		UptimeInSeconds int64     `json:"uptimeInSeconds"`
+		Timestamp       time.Time `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &jsonHealth); err != nil {
+		return err
+	}
+
+	*h = Health{
+		Status:    jsonHealth.Status,
+		Message:   jsonHealth.Message,
+		Version:   jsonHealth.Version,
+		Uptime:    time.Duration(jsonHealth.UptimeInSeconds) * time.Second,
+		Timestamp: jsonHealth.Timestamp,
+	}
+
+	return nil
+}
+
+// Backoff returns the delay before the next attempt, attempt starts at 1.
+type Backoff func(attempt int) time.Duration
+
+// ConstantBackoff returns a Backoff waiting interval between the attempts.
+func ConstantBackoff(interval time.Duration) Backoff {
+	return func(int) time.Duration {
+		return interval
+	}
+}
+
+// ExponentialBackoff returns a Backoff doubling the delay from initial up to limit.
+func ExponentialBackoff(initial, limit time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := initial
+		for i := 1; i < attempt && delay < limit; i++ {
+			delay *= 2
+		}
+		return min(delay, limit)
+	}
+}
@@ -0,0 +1,22 @@
+package wiremock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+
+	for attempt, expected := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		50: time.Second,
+	} {
+		if delay := backoff(attempt); delay != expected {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, expected, delay)
+		}
+	}
+}
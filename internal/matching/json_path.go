@@ -0,0 +1,381 @@
+package matching
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a JSON path expression evaluated locally. It supports the subset used by request matchers:
+// $.name, $['name'], $[0], $[-1], $.*, $[*], $..name and filters such as $.items[?(@.id == 1)] or $[?(@.name)].
+type jsonPath []jsonPathSegment
+
+type jsonPathSegment struct {
+	kind   jsonPathSegmentKind
+	name   string
+	index  int
+	filter *jsonPathFilter
+}
+
+type jsonPathSegmentKind int
+
+const (
+	segmentChild jsonPathSegmentKind = iota
+	segmentIndex
+	segmentWildcard
+	segmentDescendant
+	segmentFilter
+)
+
+type jsonPathFilter struct {
+	path     jsonPath
+	operator string
+	value    interface{}
+	pattern  *regexp.Regexp
+}
+
+var filterOperators = []string{"==", "!=", "<=", ">=", "=~", "<", ">"}
+
+func parseJSONPath(expression string) (jsonPath, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(expression), "$")
+	if !ok {
+		return nil, fmt.Errorf("JSON path %q must start with $", expression)
+	}
+
+	path, err := parseJSONPathSegments(rest, true)
+	if err != nil {
+		return nil, fmt.Errorf("JSON path %q: %w", expression, err)
+	}
+
+	return path, nil
+}
+
+func parseJSONPathSegments(rest string, allowIndefinite bool) (jsonPath, error) {
+	var path jsonPath
+	for rest != "" {
+		var segment jsonPathSegment
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			name, remaining := cutJSONPathName(rest[2:])
+			if name == "" || name == "*" {
+				return nil, fmt.Errorf("unsupported segment %q", rest)
+			}
+			segment, rest = jsonPathSegment{kind: segmentDescendant, name: name}, remaining
+		case strings.HasPrefix(rest, "."):
+			name, remaining := cutJSONPathName(rest[1:])
+			switch name {
+			case "":
+				return nil, fmt.Errorf("unsupported segment %q", rest)
+			case "*":
+				segment = jsonPathSegment{kind: segmentWildcard}
+			default:
+				segment = jsonPathSegment{kind: segmentChild, name: name}
+			}
+			rest = remaining
+		case strings.HasPrefix(rest, "["):
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed bracket in %q", rest)
+			}
+
+			var err error
+			if segment, err = parseJSONPathBracket(rest[1:end]); err != nil {
+				return nil, err
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unsupported segment %q", rest)
+		}
+
+		if !allowIndefinite && !segment.definite() {
+			return nil, fmt.Errorf("unsupported indefinite segment in filter")
+		}
+		path = append(path, segment)
+	}
+
+	return path, nil
+}
+
+func cutJSONPathName(rest string) (string, string) {
+	end := strings.IndexAny(rest, ".[")
+	if end < 0 {
+		return rest, ""
+	}
+	return rest[:end], rest[end:]
+}
+
+// closingBracket returns the index of the bracket closing the one at the start of s, skipping quoted strings.
+func closingBracket(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseJSONPathBracket(content string) (jsonPathSegment, error) {
+	content = strings.TrimSpace(content)
+	switch {
+	case content == "*":
+		return jsonPathSegment{kind: segmentWildcard}, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		filter, err := parseJSONPathFilter(content[2 : len(content)-1])
+		if err != nil {
+			return jsonPathSegment{}, err
+		}
+		return jsonPathSegment{kind: segmentFilter, filter: filter}, nil
+	}
+
+	if name, ok := unquote(content); ok {
+		return jsonPathSegment{kind: segmentChild, name: name}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return jsonPathSegment{}, fmt.Errorf("unsupported segment [%s]", content)
+	}
+	return jsonPathSegment{kind: segmentIndex, index: index}, nil
+}
+
+func parseJSONPathFilter(expression string) (*jsonPathFilter, error) {
+	expression = strings.TrimSpace(expression)
+	if strings.Contains(expression, "&&") || strings.Contains(expression, "||") {
+		return nil, fmt.Errorf("unsupported filter %q", expression)
+	}
+
+	left, right, operator := expression, "", ""
+	position := len(expression)
+	for _, candidate := range filterOperators {
+		if i := strings.Index(expression, candidate); i >= 0 && i < position {
+			position, operator = i, candidate
+		}
+	}
+	if operator != "" {
+		left, right = strings.TrimSpace(expression[:position]), strings.TrimSpace(expression[position+len(operator):])
+	}
+
+	rest, ok := strings.CutPrefix(left, "@")
+	if !ok {
+		return nil, fmt.Errorf("unsupported filter %q", expression)
+	}
+
+	path, err := parseJSONPathSegments(rest, false)
+	if err != nil {
+		return nil, fmt.Errorf("filter %q: %w", expression, err)
+	}
+
+	filter := &jsonPathFilter{path: path, operator: operator}
+	switch {
+	case operator == "":
+	case operator == "=~":
+		pattern, err := parseFilterRegex(right)
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", expression, err)
+		}
+		filter.pattern = pattern
+	default:
+		value, err := parseFilterLiteral(right)
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", expression, err)
+		}
+		filter.value = value
+	}
+
+	return filter, nil
+}
+
+func parseFilterRegex(literal string) (*regexp.Regexp, error) {
+	if len(literal) < 2 || literal[0] != '/' {
+		return nil, fmt.Errorf("unsupported regular expression %s", literal)
+	}
+
+	end := strings.LastIndexByte(literal, '/')
+	if end <= 0 {
+		return nil, fmt.Errorf("unterminated regular expression %s", literal)
+	}
+
+	pattern, flags := literal[1:end], literal[end+1:]
+	switch flags {
+	case "":
+	case "i":
+		pattern = "(?i)" + pattern
+	default:
+		return nil, fmt.Errorf("unsupported regular expression flags %s", flags)
+	}
+
+	return regexp.Compile(pattern)
+}
+
+func parseFilterLiteral(literal string) (interface{}, error) {
+	if value, ok := unquote(literal); ok {
+		return value, nil
+	}
+
+	switch literal {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	number, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported literal %s", literal)
+	}
+	return number, nil
+}
+
+func unquote(s string) (string, bool) {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1], true
+	}
+	return "", false
+}
+
+func (s jsonPathSegment) definite() bool {
+	return s.kind == segmentChild || s.kind == segmentIndex
+}
+
+// definite reports whether the path selects at most one value.
+func (p jsonPath) definite() bool {
+	for _, segment := range p {
+		if !segment.definite() {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate returns the values selected by the path.
+func (p jsonPath) evaluate(document interface{}) []interface{} {
+	nodes := []interface{}{document}
+	for _, segment := range p {
+		var next []interface{}
+		for _, node := range nodes {
+			next = segment.apply(node, next)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func (s jsonPathSegment) apply(node interface{}, results []interface{}) []interface{} {
+	switch s.kind {
+	case segmentChild:
+		if object, ok := node.(map[string]interface{}); ok {
+			if value, ok := object[s.name]; ok {
+				results = append(results, value)
+			}
+		}
+	case segmentIndex:
+		if array, ok := node.([]interface{}); ok {
+			index := s.index
+			if index < 0 {
+				index += len(array)
+			}
+			if index >= 0 && index < len(array) {
+				results = append(results, array[index])
+			}
+		}
+	case segmentWildcard:
+		results = append(results, children(node)...)
+	case segmentDescendant:
+		if object, ok := node.(map[string]interface{}); ok {
+			if value, ok := object[s.name]; ok {
+				results = append(results, value)
+			}
+		}
+		for _, child := range children(node) {
+			results = s.apply(child, results)
+		}
+	case segmentFilter:
+		candidates := []interface{}{node}
+		if array, ok := node.([]interface{}); ok {
+			candidates = array
+		}
+		for _, candidate := range candidates {
+			if s.filter.matches(candidate) {
+				results = append(results, candidate)
+			}
+		}
+	}
+	return results
+}
+
+func children(node interface{}) []interface{} {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		values := make([]interface{}, 0, len(node))
+		for _, key := range slices.Sorted(maps.Keys(node)) {
+			values = append(values, node[key])
+		}
+		return values
+	case []interface{}:
+		return node
+	}
+	return nil
+}
+
+func (f *jsonPathFilter) matches(node interface{}) bool {
+	values := f.path.evaluate(node)
+	if len(values) == 0 {
+		return false
+	}
+
+	value := values[0]
+	switch f.operator {
+	case "":
+		return true
+	case "=~":
+		s, ok := value.(string)
+		return ok && f.pattern.MatchString(s)
+	case "==":
+		return value == f.value
+	case "!=":
+		return value != f.value
+	}
+
+	switch expected := f.value.(type) {
+	case float64:
+		actual, ok := value.(float64)
+		return ok && compareOrdered(actual, expected, f.operator)
+	case string:
+		actual, ok := value.(string)
+		return ok && compareOrdered(actual, expected, f.operator)
+	}
+	return false
+}
+
+func compareOrdered[T float64 | string](actual, expected T, operator string) bool {
+	switch operator {
+	case "<":
+		return actual < expected
+	case "<=":
+		return actual <= expected
+	case ">":
+		return actual > expected
+	case ">=":
+		return actual >= expected
+	}
+	return false
+}
@@ -0,0 +1,379 @@
+// Package matching evaluates WireMock matcher definitions locally, e.g. to diff near misses or to match webhook calls.
+package matching
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Func evaluates a matcher against the values of a request field, present is false when the field is absent.
+type Func func(values []string, present bool) bool
+
+// stringMatcher evaluates a string value matcher against a single value.
+type stringMatcher func(value string) bool
+
+// ErrUnsupported is returned by Compile for the matchers which are only evaluated by WireMock.
+var ErrUnsupported = errors.New("matcher can't be evaluated locally")
+
+// matcherFlags are the boolean options of the string value matchers.
+var matcherFlags = map[string]bool{
+	"caseInsensitive":     true,
+	"ignoreArrayOrder":    true,
+	"ignoreExtraElements": true,
+}
+
+// Compile returns the local evaluation of a matcher definition, e.g. {"equalTo": "value", "caseInsensitive": true},
+// or an error wrapping ErrUnsupported when the matcher is only evaluated by WireMock, e.g. equalToXml.
+func Compile(matcher map[string]interface{}) (Func, error) {
+	if absent, _ := matcher["absent"].(bool); absent {
+		return func(_ []string, present bool) bool { return !present }, nil
+	}
+
+	for _, operator := range []string{"and", "or"} {
+		if operands, ok := matcher[operator]; ok {
+			return compileLogical(operator, operands)
+		}
+	}
+
+	if operand, ok := matcher["not"]; ok {
+		compiled, err := compileOperand(operand)
+		if err != nil {
+			return nil, fmt.Errorf("not: %w", err)
+		}
+		return func(values []string, present bool) bool { return !compiled(values, present) }, nil
+	}
+
+	for _, strategy := range []string{"hasExactly", "includes"} {
+		if operands, ok := matcher[strategy]; ok {
+			return compileMultiValue(strategy, operands)
+		}
+	}
+
+	match, err := compileStringMatcher(matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(values []string, present bool) bool {
+		return present && slices.ContainsFunc(values, match)
+	}, nil
+}
+
+func compileOperand(operand interface{}) (Func, error) {
+	matcher, ok := operand.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: operand is not a matcher: %v", ErrUnsupported, operand)
+	}
+
+	return Compile(matcher)
+}
+
+func compileOperands(operands interface{}) ([]Func, error) {
+	list, ok := operands.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: operands are not a list: %v", ErrUnsupported, operands)
+	}
+
+	compiled := make([]Func, len(list))
+	for i, operand := range list {
+		var err error
+		if compiled[i], err = compileOperand(operand); err != nil {
+			return nil, err
+		}
+	}
+
+	return compiled, nil
+}
+
+func compileLogical(operator string, operands interface{}) (Func, error) {
+	compiled, err := compileOperands(operands)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operator, err)
+	}
+
+	if operator == "and" {
+		return func(values []string, present bool) bool {
+			for _, operand := range compiled {
+				if !operand(values, present) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	}
+
+	return func(values []string, present bool) bool {
+		for _, operand := range compiled {
+			if operand(values, present) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// compileMultiValue evaluates hasExactly and includes, every matcher has to match one of the values.
+// For hasExactly every value has to be matched as well.
+func compileMultiValue(strategy string, operands interface{}) (Func, error) {
+	compiled, err := compileOperands(operands)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strategy, err)
+	}
+
+	matchesValue := func(operand Func, value string) bool {
+		return operand([]string{value}, true)
+	}
+
+	return func(values []string, present bool) bool {
+		if !present {
+			return false
+		}
+
+		for _, operand := range compiled {
+			if !slices.ContainsFunc(values, func(value string) bool { return matchesValue(operand, value) }) {
+				return false
+			}
+		}
+
+		if strategy == "includes" {
+			return true
+		}
+
+		for _, value := range values {
+			if !slices.ContainsFunc(compiled, func(operand Func) bool { return matchesValue(operand, value) }) {
+				return false
+			}
+		}
+		return len(values) == len(compiled)
+	}, nil
+}
+
+func compileStringMatcher(matcher map[string]interface{}) (stringMatcher, error) {
+	var strategy string
+	for _, key := range slices.Sorted(maps.Keys(matcher)) {
+		if matcherFlags[key] {
+			continue
+		}
+
+		if strategy != "" {
+			return nil, fmt.Errorf("%w: unsupported option %s of %s", ErrUnsupported, key, strategy)
+		}
+		strategy = key
+	}
+
+	caseInsensitive, _ := matcher["caseInsensitive"].(bool)
+	expected, isString := matcher[strategy].(string)
+	switch {
+	case strategy == "equalToJson":
+		return compileEqualToJSON(matcher)
+	case strategy == "matchesJsonPath":
+		return compileMatchesJSONPath(matcher[strategy])
+	case !isString:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, strategy)
+	}
+
+	switch strategy {
+	case "equalTo":
+		if caseInsensitive {
+			return func(value string) bool { return strings.EqualFold(expected, value) }, nil
+		}
+		return func(value string) bool { return expected == value }, nil
+	case "contains":
+		return func(value string) bool { return strings.Contains(value, expected) }, nil
+	case "doesNotContain":
+		return func(value string) bool { return !strings.Contains(value, expected) }, nil
+	case "matches", "doesNotMatch":
+		re, err := regexp.Compile(`^(?:` + expected + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrUnsupported, strategy, err)
+		}
+		return func(value string) bool { return re.MatchString(value) == (strategy == "matches") }, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupported, strategy)
+}
+
+// compileEqualToJSON compares JSON values semantically, honouring the ignoreArrayOrder and ignoreExtraElements flags.
+func compileEqualToJSON(matcher map[string]interface{}) (stringMatcher, error) {
+	var expected interface{}
+	switch value := matcher["equalToJson"].(type) {
+	case string:
+		if err := json.Unmarshal([]byte(value), &expected); err != nil {
+			return nil, fmt.Errorf("%w: equalToJson: %w", ErrUnsupported, err)
+		}
+	default:
+		expected = value
+	}
+
+	ignoreArrayOrder, _ := matcher["ignoreArrayOrder"].(bool)
+	ignoreExtraElements, _ := matcher["ignoreExtraElements"].(bool)
+	options := jsonEqualOptions{ignoreArrayOrder: ignoreArrayOrder, ignoreExtraElements: ignoreExtraElements}
+
+	return func(value string) bool {
+		var actual interface{}
+		if err := json.Unmarshal([]byte(value), &actual); err != nil {
+			return false
+		}
+		return options.equal(expected, actual)
+	}, nil
+}
+
+// compileMatchesJSONPath matches when the expression selects a value, or when the selected value matches
+// the sub-matcher of {"expression": "$.id", "equalTo": "1"}. Objects and arrays are matched in their JSON encoding.
+func compileMatchesJSONPath(value interface{}) (stringMatcher, error) {
+	var expression string
+	var subMatcher Func
+	switch value := value.(type) {
+	case string:
+		expression = value
+	case map[string]interface{}:
+		expression, _ = value["expression"].(string)
+
+		sub := maps.Clone(value)
+		delete(sub, "expression")
+
+		var err error
+		if subMatcher, err = Compile(sub); err != nil {
+			return nil, fmt.Errorf("matchesJsonPath: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: matchesJsonPath: %v", ErrUnsupported, value)
+	}
+
+	path, err := parseJSONPath(expression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: matchesJsonPath: %w", ErrUnsupported, err)
+	}
+
+	return func(body string) bool {
+		var document interface{}
+		if err := json.Unmarshal([]byte(body), &document); err != nil {
+			return false
+		}
+
+		results := path.evaluate(document)
+		if subMatcher == nil {
+			if path.definite() {
+				return len(results) == 1 && !isEmptyJSON(results[0])
+			}
+			return len(results) > 0
+		}
+
+		values := make([]string, len(results))
+		for i, result := range results {
+			values[i] = jsonString(result)
+		}
+		return subMatcher(values, len(values) > 0)
+	}, nil
+}
+
+// jsonString returns strings as they are and other JSON values in their encoded form.
+func jsonString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+// isEmptyJSON reports whether the value is null, an empty object or an empty array, which JSON path doesn't match.
+func isEmptyJSON(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+type jsonEqualOptions struct {
+	ignoreArrayOrder    bool
+	ignoreExtraElements bool
+}
+
+// jsonPlaceholders are the JsonUnit placeholders supported by equalToJson.
+var jsonPlaceholders = map[string]func(actual interface{}) bool{
+	"${json-unit.ignore}":         func(interface{}) bool { return true },
+	"${json-unit.any-string}":     func(actual interface{}) bool { _, ok := actual.(string); return ok },
+	"${json-unit.any-number}":     func(actual interface{}) bool { _, ok := actual.(float64); return ok },
+	"${json-unit.any-boolean}":    func(actual interface{}) bool { _, ok := actual.(bool); return ok },
+	"${json-unit.ignore-element}": func(interface{}) bool { return true },
+}
+
+func (o jsonEqualOptions) equal(expected, actual interface{}) bool {
+	switch expected := expected.(type) {
+	case map[string]interface{}:
+		actual, ok := actual.(map[string]interface{})
+		if !ok || (!o.ignoreExtraElements && len(expected) != len(actual)) {
+			return false
+		}
+
+		for key, value := range expected {
+			actualValue, ok := actual[key]
+			if !ok || !o.equal(value, actualValue) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actual, ok := actual.([]interface{})
+		if !ok || len(actual) < len(expected) || (!o.ignoreExtraElements && len(expected) != len(actual)) {
+			return false
+		}
+
+		if o.ignoreArrayOrder {
+			return o.matchUnordered(expected, actual, make([]bool, len(actual)))
+		}
+
+		for i := range expected {
+			if !o.equal(expected[i], actual[i]) {
+				return false
+			}
+		}
+		return true
+	case string:
+		if placeholder, ok := jsonPlaceholders[expected]; ok {
+			return placeholder(actual)
+		}
+
+		if pattern, ok := strings.CutPrefix(expected, "${json-unit.regex}"); ok {
+			actual, isString := actual.(string)
+			re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+			return isString && err == nil && re.MatchString(actual)
+		}
+
+		return expected == actual
+	default:
+		return expected == actual
+	}
+}
+
+// matchUnordered pairs every expected element with a distinct actual element.
+func (o jsonEqualOptions) matchUnordered(expected, actual []interface{}, used []bool) bool {
+	if len(expected) == 0 {
+		return true
+	}
+
+	for i := range actual {
+		if used[i] || !o.equal(expected[0], actual[i]) {
+			continue
+		}
+
+		used[i] = true
+		if o.matchUnordered(expected[1:], actual, used) {
+			return true
+		}
+		used[i] = false
+	}
+
+	return false
+}
@@ -0,0 +1,84 @@
+package matching
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		matcher  string
+		values   []string
+		expected bool
+	}{
+		{name: "equalToJson", matcher: `{"equalToJson": "{\"id\": 1, \"tags\": [\"a\", \"b\"]}"}`, values: []string{`{"tags":["a","b"],"id":1}`}, expected: true},
+		{name: "equalToJson different value", matcher: `{"equalToJson": "{\"id\": 1}"}`, values: []string{`{"id":2}`}, expected: false},
+		{name: "equalToJson extra field", matcher: `{"equalToJson": "{\"id\": 1}"}`, values: []string{`{"id":1,"name":"a"}`}, expected: false},
+		{name: "equalToJson ignoreExtraElements", matcher: `{"equalToJson": "{\"id\": 1}", "ignoreExtraElements": true}`, values: []string{`{"id":1,"name":"a"}`}, expected: true},
+		{name: "equalToJson array order", matcher: `{"equalToJson": "[1, 2]"}`, values: []string{`[2,1]`}, expected: false},
+		{name: "equalToJson ignoreArrayOrder", matcher: `{"equalToJson": "[1, 2, 1]", "ignoreArrayOrder": true}`, values: []string{`[1,1,2]`}, expected: true},
+		{name: "equalToJson object value", matcher: `{"equalToJson": {"id": 1}}`, values: []string{`{"id":1}`}, expected: true},
+		{name: "equalToJson placeholder", matcher: `{"equalToJson": "{\"id\": \"${json-unit.any-number}\", \"name\": \"${json-unit.regex}[a-z]+\"}"}`, values: []string{`{"id":7,"name":"abc"}`}, expected: true},
+		{name: "equalToJson invalid body", matcher: `{"equalToJson": "{}"}`, values: []string{`not json`}, expected: false},
+		{name: "matchesJsonPath", matcher: `{"matchesJsonPath": "$.items[0].id"}`, values: []string{`{"items":[{"id":1}]}`}, expected: true},
+		{name: "matchesJsonPath missing", matcher: `{"matchesJsonPath": "$.items[1].id"}`, values: []string{`{"items":[{"id":1}]}`}, expected: false},
+		{name: "matchesJsonPath null", matcher: `{"matchesJsonPath": "$.id"}`, values: []string{`{"id":null}`}, expected: false},
+		{name: "matchesJsonPath filter", matcher: `{"matchesJsonPath": "$.items[?(@.price > 10)]"}`, values: []string{`{"items":[{"price":5},{"price":15}]}`}, expected: true},
+		{name: "matchesJsonPath filter no match", matcher: `{"matchesJsonPath": "$.items[?(@.name == 'b')]"}`, values: []string{`{"items":[{"name":"a"}]}`}, expected: false},
+		{name: "matchesJsonPath descendant", matcher: `{"matchesJsonPath": "$..name"}`, values: []string{`{"a":{"b":{"name":"x"}}}`}, expected: true},
+		{name: "matchesJsonPath sub-matcher", matcher: `{"matchesJsonPath": {"expression": "$['user'].name", "equalTo": "Tom"}}`, values: []string{`{"user":{"name":"Tom"}}`}, expected: true},
+		{name: "matchesJsonPath sub-matcher json", matcher: `{"matchesJsonPath": {"expression": "$.user", "equalToJson": "{\"name\": \"Tom\"}"}}`, values: []string{`{"user":{"name":"Tom"}}`}, expected: true},
+		{name: "and", matcher: `{"and": [{"contains": "json"}, {"contains": "app"}]}`, values: []string{"application/json"}, expected: true},
+		{name: "and mismatch", matcher: `{"and": [{"contains": "json"}, {"contains": "xml"}]}`, values: []string{"application/json"}, expected: false},
+		{name: "or", matcher: `{"or": [{"equalTo": "a"}, {"absent": true}]}`, values: nil, expected: true},
+		{name: "not", matcher: `{"not": {"equalTo": "a"}}`, values: []string{"b"}, expected: true},
+		{name: "hasExactly", matcher: `{"hasExactly": [{"equalTo": "a"}, {"equalTo": "b"}]}`, values: []string{"b", "a"}, expected: true},
+		{name: "hasExactly extra value", matcher: `{"hasExactly": [{"equalTo": "a"}]}`, values: []string{"a", "b"}, expected: false},
+		{name: "includes", matcher: `{"includes": [{"equalTo": "a"}]}`, values: []string{"a", "b"}, expected: true},
+		{name: "any value", matcher: `{"equalTo": "b"}`, values: []string{"a", "b"}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var matcher map[string]interface{}
+			if err := json.Unmarshal([]byte(tc.matcher), &matcher); err != nil {
+				t.Fatalf("json.Unmarshal error: %v", err)
+			}
+
+			match, err := Compile(matcher)
+			if err != nil {
+				t.Fatalf("Compile error: %v", err)
+			}
+
+			if actual := match(tc.values, tc.values != nil); actual != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCompile_Unsupported(t *testing.T) {
+	for _, matcher := range []string{
+		`{"equalToXml": "<a/>"}`,
+		`{"matchesXPath": "/a", "xPathNamespaces": {"a": "urn:a"}}`,
+		`{"and": [{"contains": "a"}, {"matchesJsonSchema": "{}"}]}`,
+		`{"matchesJsonPath": "$.items[0:2]"}`,
+		`{"matchesJsonPath": "$.items[?(@.a == 1 && @.b == 2)]"}`,
+		`{"matchesJsonPath": "$.items[?(@.name =~ /abc)]"}`,
+		`{"matchesJsonPath": {"expression": "$.a", "equalToXml": "<a/>"}}`,
+		`{"matches": "("}`,
+	} {
+		t.Run(matcher, func(t *testing.T) {
+			var m map[string]interface{}
+			if err := json.Unmarshal([]byte(matcher), &m); err != nil {
+				t.Fatalf("json.Unmarshal error: %v", err)
+			}
+
+			if _, err := Compile(m); !errors.Is(err, ErrUnsupported) {
+				t.Errorf("expected ErrUnsupported, got %v", err)
+			}
+		})
+	}
+}
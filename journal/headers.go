@@ -0,0 +1,116 @@
+package journal
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Headers are HTTP headers logged by WireMock, a header is logged as a single value or an array when repeated.
+type Headers map[string][]string
+
+// Cookies are cookies logged by WireMock, a cookie is logged as a single value or an array when repeated.
+type Cookies map[string][]string
+
+// Get returns the first value of the header, the name is case-insensitive.
+func (h Headers) Get(name string) string {
+	values := h.Values(name)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Values returns all values of the header, the name is case-insensitive.
+func (h Headers) Values(name string) []string {
+	for key, values := range h {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (h *Headers) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalMultiValues(data)
+	if err != nil {
+		return err
+	}
+
+	*h = values
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (h Headers) MarshalJSON() ([]byte, error) {
+	return marshalMultiValues(h)
+}
+
+// Get returns the first value of the cookie.
+func (c Cookies) Get(name string) string {
+	if len(c[name]) == 0 {
+		return ""
+	}
+	return c[name][0]
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *Cookies) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalMultiValues(data)
+	if err != nil {
+		return err
+	}
+
+	*c = values
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c Cookies) MarshalJSON() ([]byte, error) {
+	return marshalMultiValues(c)
+}
+
+func unmarshalMultiValues(data []byte) (map[string][]string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	if raw == nil {
+		return nil, nil
+	}
+
+	values := make(map[string][]string, len(raw))
+	for key, value := range raw {
+		var single string
+		if err := json.Unmarshal(value, &single); err == nil {
+			values[key] = []string{single}
+			continue
+		}
+
+		var multiple []string
+		if err := json.Unmarshal(value, &multiple); err != nil {
+			return nil, err
+		}
+		values[key] = multiple
+	}
+
+	return values, nil
+}
+
+func marshalMultiValues(values map[string][]string) ([]byte, error) {
+	if values == nil {
+		return []byte("null"), nil
+	}
+
+	raw := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if len(value) == 1 {
+			raw[key] = value[0]
+		} else {
+			raw[key] = value
+		}
+	}
+
+	return json.Marshal(raw)
+}
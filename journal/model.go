@@ -1,8 +1,6 @@
 package journal
 
-type Cookies map[string]string
-
-type Headers map[string]string
+import "time"
 
 type Params map[string]Param
 
@@ -25,6 +23,8 @@ type GetRequestResponse struct {
 	WasMatched         bool               `json:"wasMatched,omitempty"`
 	Timing             Timing             `json:"timing,omitempty"`
 	StubMapping        StubMapping        `json:"stubMapping,omitempty"`
+	SubEvents          []SubEvent         `json:"subEvents,omitempty"`
+	PostServeActions   []PostServeAction  `json:"postServeActions,omitempty"`
 }
 
 type FindRequestsByCriteriaResponse struct {
@@ -40,30 +40,58 @@ type DeleteRequestByCriteriaResponse struct {
 }
 
 type Request struct {
-	URL                 string  `json:"url,omitempty"`
-	AbsoluteURL         string  `json:"absoluteUrl,omitempty"`
-	Method              string  `json:"method,omitempty"`
-	ClientIP            string  `json:"clientIp,omitempty"`
-	Headers             Headers `json:"headers,omitempty"`
-	Cookies             Cookies `json:"cookies,omitempty"`
-	BrowserProxyRequest bool    `json:"browserProxyRequest,omitempty"`
-	LoggedDate          int64   `json:"loggedDate,omitempty"`
-	BodyAsBase64        string  `json:"bodyAsBase64,omitempty"`
-	Body                string  `json:"body,omitempty"`
-	Protocol            string  `json:"protocol,omitempty"`
-	Scheme              string  `json:"scheme,omitempty"`
-	LoggedDateString    string  `json:"loggedDateString,omitempty"`
-	Host                string  `json:"host,omitempty"`
-	Port                int64   `json:"port,omitempty"`
-	QueryParams         Params  `json:"queryParams,omitempty"`
-	FormParams          Params  `json:"formParams,omitempty"`
+	URL                 string    `json:"url,omitempty"`
+	AbsoluteURL         string    `json:"absoluteUrl,omitempty"`
+	Method              string    `json:"method,omitempty"`
+	ClientIP            string    `json:"clientIp,omitempty"`
+	Headers             Headers   `json:"headers,omitempty"`
+	Cookies             Cookies   `json:"cookies,omitempty"`
+	BrowserProxyRequest bool      `json:"browserProxyRequest,omitempty"`
+	LoggedDate          time.Time `json:"-"`
+	BodyAsBase64        string    `json:"bodyAsBase64,omitempty"`
+	Body                string    `json:"body,omitempty"`
+	Protocol            string    `json:"protocol,omitempty"`
+	Scheme              string    `json:"scheme,omitempty"`
+	LoggedDateString    string    `json:"loggedDateString,omitempty"`
+	Host                string    `json:"host,omitempty"`
+	Port                int64     `json:"port,omitempty"`
+	QueryParams         Params    `json:"queryParams,omitempty"`
+	FormParams          Params    `json:"formParams,omitempty"`
 }
 
 type ResponseDefinition struct {
-	Headers            Headers `json:"headers,omitempty"`
-	Body               string  `json:"body,omitempty"`
-	Status             int64   `json:"status,omitempty"`
-	FromConfiguredStub bool    `json:"fromConfiguredStub,omitempty"`
+	Headers                       Headers                `json:"headers,omitempty"`
+	Body                          string                 `json:"body,omitempty"`
+	Base64Body                    string                 `json:"base64Body,omitempty"`
+	JSONBody                      interface{}            `json:"jsonBody,omitempty"`
+	BodyFileName                  string                 `json:"bodyFileName,omitempty"`
+	Status                        int64                  `json:"status,omitempty"`
+	StatusMessage                 string                 `json:"statusMessage,omitempty"`
+	FixedDelayMilliseconds        int64                  `json:"fixedDelayMilliseconds,omitempty"`
+	DelayDistribution             *DelayDistribution     `json:"delayDistribution,omitempty"`
+	ChunkedDribbleDelay           *ChunkedDribbleDelay   `json:"chunkedDribbleDelay,omitempty"`
+	Fault                         string                 `json:"fault,omitempty"`
+	Transformers                  []string               `json:"transformers,omitempty"`
+	TransformerParameters         map[string]interface{} `json:"transformerParameters,omitempty"`
+	ProxyBaseURL                  string                 `json:"proxyBaseUrl,omitempty"`
+	AdditionalProxyRequestHeaders map[string]string      `json:"additionalProxyRequestHeaders,omitempty"`
+	RemoveProxyRequestHeaders     []string               `json:"removeProxyRequestHeaders,omitempty"`
+	ProxyURLPrefixToRemove        string                 `json:"proxyUrlPrefixToRemove,omitempty"`
+	FromConfiguredStub            bool                   `json:"fromConfiguredStub,omitempty"`
+}
+
+type DelayDistribution struct {
+	Type         string  `json:"type,omitempty"`
+	Milliseconds int64   `json:"milliseconds,omitempty"`
+	Median       int64   `json:"median,omitempty"`
+	Sigma        float64 `json:"sigma,omitempty"`
+	Lower        int64   `json:"lower,omitempty"`
+	Upper        int64   `json:"upper,omitempty"`
+}
+
+type ChunkedDribbleDelay struct {
+	NumberOfChunks int64 `json:"numberOfChunks,omitempty"`
+	TotalDuration  int64 `json:"totalDuration,omitempty"`
 }
 
 type Response struct {
@@ -74,27 +102,98 @@ type Response struct {
 }
 
 type Timing struct {
-	ServeTime        int64 `json:"serveTime,omitempty"`
-	TotalTime        int64 `json:"totalTime,omitempty"`
-	ProcessTime      int64 `json:"processTime,omitempty"`
-	ResponseSendTime int64 `json:"responseSendTime,omitempty"`
-	AddedDelay       int64 `json:"addedDelay,omitempty"`
+	ServeTime        time.Duration
+	TotalTime        time.Duration
+	ProcessTime      time.Duration
+	ResponseSendTime time.Duration
+	AddedDelay       time.Duration
+}
+
+type GetAllStubsResponse struct {
+	Mappings []StubMapping `json:"mappings,omitempty"`
+	Meta     Meta          `json:"meta,omitempty"`
 }
 
 type StubMapping struct {
-	ID       string             `json:"id,omitempty"`
-	Request  StubMappingRequest `json:"request,omitempty"`
-	Response ResponseDefinition `json:"response,omitempty"`
-	UUID     string             `json:"uuid,omitempty"`
+	ID                    string                 `json:"id,omitempty"`
+	UUID                  string                 `json:"uuid,omitempty"`
+	Name                  string                 `json:"name,omitempty"`
+	Request               StubMappingRequest     `json:"request,omitempty"`
+	Response              ResponseDefinition     `json:"response,omitempty"`
+	Persistent            bool                   `json:"persistent,omitempty"`
+	Priority              int64                  `json:"priority,omitempty"`
+	ScenarioName          string                 `json:"scenarioName,omitempty"`
+	RequiredScenarioState string                 `json:"requiredScenarioState,omitempty"`
+	NewScenarioState      string                 `json:"newScenarioState,omitempty"`
+	PostServeActions      []PostServeAction      `json:"postServeActions,omitempty"`
+	ServeEventListeners   []ServeEventListener   `json:"serveEventListeners,omitempty"`
+	Metadata              map[string]interface{} `json:"metadata,omitempty"`
 }
 
 type StubMappingRequest struct {
-	Method          string `json:"method,omitempty"`
-	URL             string `json:"url,omitempty"`
-	URLPattern      string `json:"urlPattern,omitempty"`
-	URLPath         string `json:"urlPath,omitempty"`
-	URLPathPattern  string `json:"urlPathPattern,omitempty"`
-	URLPathTemplate string `json:"urlPathTemplate,omitempty"`
+	Method               string                `json:"method,omitempty"`
+	URL                  string                `json:"url,omitempty"`
+	URLPattern           string                `json:"urlPattern,omitempty"`
+	URLPath              string                `json:"urlPath,omitempty"`
+	URLPathPattern       string                `json:"urlPathPattern,omitempty"`
+	URLPathTemplate      string                `json:"urlPathTemplate,omitempty"`
+	Scheme               string                `json:"scheme,omitempty"`
+	Host                 Matcher               `json:"host,omitempty"`
+	Port                 int64                 `json:"port,omitempty"`
+	Headers              map[string]Matcher    `json:"headers,omitempty"`
+	QueryParameters      map[string]Matcher    `json:"queryParameters,omitempty"`
+	PathParameters       map[string]Matcher    `json:"pathParameters,omitempty"`
+	Cookies              map[string]Matcher    `json:"cookies,omitempty"`
+	FormParameters       map[string]Matcher    `json:"formParameters,omitempty"`
+	BodyPatterns         []Matcher             `json:"bodyPatterns,omitempty"`
+	MultipartPatterns    []MultipartPattern    `json:"multipartPatterns,omitempty"`
+	BasicAuthCredentials *BasicAuthCredentials `json:"basicAuthCredentials,omitempty"`
+}
+
+// Matcher is a matcher definition as returned by WireMock, e.g. {"equalTo": "value", "caseInsensitive": true}.
+type Matcher map[string]interface{}
+
+type MultipartPattern struct {
+	MatchingType string             `json:"matchingType,omitempty"`
+	Headers      map[string]Matcher `json:"headers,omitempty"`
+	BodyPatterns []Matcher          `json:"bodyPatterns,omitempty"`
+}
+
+type BasicAuthCredentials struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+type PostServeAction struct {
+	Name       string                 `json:"name,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type ServeEventListener struct {
+	Name          string                 `json:"name,omitempty"`
+	RequestPhases []string               `json:"requestPhases,omitempty"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type GetAllScenariosResponse struct {
+	Scenarios []Scenario `json:"scenarios,omitempty"`
+}
+
+type Scenario struct {
+	ID             string        `json:"id,omitempty"`
+	Name           string        `json:"name,omitempty"`
+	State          string        `json:"state,omitempty"`
+	PossibleStates []string      `json:"possibleStates,omitempty"`
+	Mappings       []StubMapping `json:"mappings,omitempty"`
+}
+
+// MappingIDs returns IDs of the stub mappings taking part in the scenario.
+func (s Scenario) MappingIDs() []string {
+	ids := make([]string, len(s.Mappings))
+	for i, mapping := range s.Mappings {
+		ids[i] = mapping.ID
+	}
+	return ids
 }
 
 type Meta struct {
@@ -109,4 +208,6 @@ type ServeEvent struct {
 	Timing             Timing             `json:"timing,omitempty"`
 	ID                 string             `json:"id,omitempty"`
 	ResponseDefinition ResponseDefinition `json:"responseDefinition,omitempty"`
+	SubEvents          []SubEvent         `json:"subEvents,omitempty"`
+	PostServeActions   []PostServeAction  `json:"postServeActions,omitempty"`
 }
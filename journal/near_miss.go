@@ -0,0 +1,336 @@
+package journal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/wiremock/go-wiremock/internal/matching"
+)
+
+// DiffStatus tells whether a field of the request matches its pattern.
+type DiffStatus string
+
+const (
+	DiffMatched    DiffStatus = "matched"
+	DiffMismatched DiffStatus = "mismatched"
+	// DiffUnknown is reported for matchers which are only evaluated by WireMock, e.g. equalToXml or matchesXPath.
+	DiffUnknown DiffStatus = "unknown"
+)
+
+type FindNearMissesResponse struct {
+	NearMisses []NearMiss `json:"nearMisses,omitempty"`
+}
+
+type NearMiss struct {
+	Request        Request             `json:"request,omitempty"`
+	StubMapping    *StubMapping        `json:"stubMapping,omitempty"`
+	RequestPattern *StubMappingRequest `json:"requestPattern,omitempty"`
+	MatchResult    MatchResult         `json:"matchResult,omitempty"`
+	ScenarioState  string              `json:"scenarioState,omitempty"`
+}
+
+type MatchResult struct {
+	Distance float64 `json:"distance"`
+}
+
+// FieldDiff compares a field of the request pattern with the value received.
+type FieldDiff struct {
+	Field    string     `json:"field"`
+	Expected string     `json:"expected"`
+	Actual   string     `json:"actual"`
+	Status   DiffStatus `json:"status"`
+}
+
+// Pattern returns the request pattern of the stub mapping, or the request pattern the request was compared with.
+func (n NearMiss) Pattern() StubMappingRequest {
+	if n.StubMapping != nil {
+		return n.StubMapping.Request
+	}
+
+	if n.RequestPattern != nil {
+		return *n.RequestPattern
+	}
+
+	return StubMappingRequest{}
+}
+
+// Diff compares every field of the pattern with the request.
+func (n NearMiss) Diff() []FieldDiff {
+	pattern := n.Pattern()
+	request := n.Request
+
+	var diffs []FieldDiff
+	if pattern.Method != "" && pattern.Method != "ANY" {
+		diffs = append(diffs, FieldDiff{
+			Field:    "method",
+			Expected: pattern.Method,
+			Actual:   request.Method,
+			Status:   diffStatus(pattern.Method == request.Method),
+		})
+	}
+
+	urlPath, _, _ := strings.Cut(request.URL, "?")
+	for _, url := range []struct {
+		strategy string
+		expected string
+		actual   string
+	}{
+		{strategy: "url", expected: pattern.URL, actual: request.URL},
+		{strategy: "urlPattern", expected: pattern.URLPattern, actual: request.URL},
+		{strategy: "urlPath", expected: pattern.URLPath, actual: urlPath},
+		{strategy: "urlPathPattern", expected: pattern.URLPathPattern, actual: urlPath},
+	} {
+		if url.expected == "" {
+			continue
+		}
+
+		diffs = append(diffs, matcherDiff(url.strategy, Matcher{urlStrategies[url.strategy]: url.expected}, []string{url.actual}, true))
+	}
+
+	pathParams, templateMatched := pathTemplateParams(pattern.URLPathTemplate, urlPath)
+	if pattern.URLPathTemplate != "" {
+		diffs = append(diffs, FieldDiff{
+			Field:    "urlPathTemplate",
+			Expected: pattern.URLPathTemplate,
+			Actual:   urlPath,
+			Status:   diffStatus(templateMatched),
+		})
+	}
+
+	if pattern.Scheme != "" {
+		diffs = append(diffs, valueDiff("scheme", pattern.Scheme, request.Scheme))
+	}
+
+	if pattern.Host != nil {
+		diffs = append(diffs, matcherDiff("host", pattern.Host, []string{request.Host}, request.Host != ""))
+	}
+
+	if pattern.Port != 0 {
+		actual := ""
+		if request.Port != 0 {
+			actual = strconv.FormatInt(request.Port, 10)
+		}
+		diffs = append(diffs, valueDiff("port", strconv.FormatInt(pattern.Port, 10), actual))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(pattern.Headers)) {
+		actual, present := lookupHeader(request.Headers, name)
+		diffs = append(diffs, matcherDiff("header "+name, pattern.Headers[name], actual, present))
+	}
+
+	if credentials := pattern.BasicAuthCredentials; credentials != nil {
+		diffs = append(diffs, basicAuthDiff(*credentials, request.Headers.Get("Authorization")))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(pattern.QueryParameters)) {
+		actual, present := lookupParam(request.QueryParams, name)
+		diffs = append(diffs, matcherDiff("query "+name, pattern.QueryParameters[name], actual, present))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(pattern.PathParameters)) {
+		actual, present := pathParams[name]
+		diffs = append(diffs, matcherDiff("path "+name, pattern.PathParameters[name], []string{actual}, present))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(pattern.Cookies)) {
+		actual, present := lookupCookie(request.Cookies, name)
+		diffs = append(diffs, matcherDiff("cookie "+name, pattern.Cookies[name], actual, present))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(pattern.FormParameters)) {
+		actual, present := lookupParam(request.FormParams, name)
+		diffs = append(diffs, matcherDiff("form "+name, pattern.FormParameters[name], actual, present))
+	}
+
+	body := request.Body
+	if bodyBytes, err := request.BodyBytes(); err == nil {
+		body = string(bodyBytes)
+	}
+
+	for _, bodyPattern := range pattern.BodyPatterns {
+		diffs = append(diffs, matcherDiff("body", bodyPattern, []string{body}, true))
+	}
+
+	// Multipart bodies are only matched by WireMock.
+	for _, multipartPattern := range pattern.MultipartPatterns {
+		expected, _ := json.Marshal(multipartPattern)
+		diffs = append(diffs, FieldDiff{
+			Field:    "multipart",
+			Expected: string(expected),
+			Status:   DiffUnknown,
+		})
+	}
+
+	return diffs
+}
+
+// valueDiff compares a field with its expected value, a field missing from the logged request is not evaluated.
+func valueDiff(field, expected, actual string) FieldDiff {
+	diff := FieldDiff{Field: field, Expected: expected, Actual: actual, Status: DiffUnknown}
+	if actual != "" {
+		diff.Status = diffStatus(strings.EqualFold(expected, actual))
+	}
+
+	return diff
+}
+
+// basicAuthDiff compares the credentials with the Authorization header, the password is not shown.
+func basicAuthDiff(credentials BasicAuthCredentials, authorization string) FieldDiff {
+	diff := FieldDiff{Field: "basicAuth", Expected: credentials.Username, Status: DiffMismatched}
+
+	encoded, ok := strings.CutPrefix(authorization, "Basic ")
+	if !ok {
+		return diff
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return diff
+	}
+
+	username, password, _ := strings.Cut(string(decoded), ":")
+	diff.Actual = username
+	diff.Status = diffStatus(username == credentials.Username && password == credentials.Password)
+
+	return diff
+}
+
+// pathTemplateParams returns the path parameters of urlPath, e.g. {"id": "1"} for /users/{id} and /users/1,
+// matched is false when urlPath doesn't follow the template.
+func pathTemplateParams(template, urlPath string) (params map[string]string, matched bool) {
+	if template == "" {
+		return nil, false
+	}
+
+	templateSegments := strings.Split(template, "/")
+	pathSegments := strings.Split(urlPath, "/")
+	if len(templateSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	params = make(map[string]string)
+	for i, segment := range templateSegments {
+		if name, ok := strings.CutPrefix(segment, "{"); ok && strings.HasSuffix(name, "}") {
+			value, err := url.PathUnescape(pathSegments[i])
+			if err != nil || value == "" {
+				return nil, false
+			}
+			params[strings.TrimSuffix(name, "}")] = value
+			continue
+		}
+
+		if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// Format returns a readable diff between the pattern and the request.
+func (n NearMiss) Format() string {
+	var sb strings.Builder
+
+	switch {
+	case n.StubMapping != nil && n.StubMapping.Name != "":
+		fmt.Fprintf(&sb, "Stub %q (%s), distance %.4f\n", n.StubMapping.Name, n.StubMapping.ID, n.MatchResult.Distance)
+	case n.StubMapping != nil:
+		fmt.Fprintf(&sb, "Stub %s, distance %.4f\n", n.StubMapping.ID, n.MatchResult.Distance)
+	default:
+		fmt.Fprintf(&sb, "Request %s %s, distance %.4f\n", n.Request.Method, n.Request.URL, n.MatchResult.Distance)
+	}
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FIELD\tEXPECTED\tACTUAL\t")
+	for _, diff := range n.Diff() {
+		marker := ""
+		switch diff.Status {
+		case DiffMismatched:
+			marker = "<<<<< does not match"
+		case DiffUnknown:
+			marker = "(not evaluated)"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", diff.Field, oneLine(diff.Expected), oneLine(diff.Actual), marker)
+	}
+	_ = w.Flush()
+
+	return sb.String()
+}
+
+var urlStrategies = map[string]string{
+	"url":            "equalTo",
+	"urlPattern":     "matches",
+	"urlPath":        "equalTo",
+	"urlPathPattern": "matches",
+}
+
+func matcherDiff(field string, matcher Matcher, values []string, present bool) FieldDiff {
+	diff := FieldDiff{
+		Field:    field,
+		Expected: describeMatcher(matcher),
+		Actual:   strings.Join(values, ", "),
+		Status:   DiffUnknown,
+	}
+
+	if match, err := matching.Compile(matcher); err == nil {
+		diff.Status = diffStatus(match(values, present))
+	}
+
+	return diff
+}
+
+// describeMatcher returns the matcher as "strategy value, flag".
+func describeMatcher(matcher Matcher) string {
+	parts := make([]string, 0, len(matcher))
+	for _, key := range slices.Sorted(maps.Keys(matcher)) {
+		switch value := matcher[key].(type) {
+		case bool:
+			if value {
+				parts = append(parts, key)
+			}
+		case string:
+			parts = append(parts, key+" "+value)
+		default:
+			encoded, _ := json.Marshal(value)
+			parts = append(parts, key+" "+string(encoded))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func diffStatus(matched bool) DiffStatus {
+	if matched {
+		return DiffMatched
+	}
+	return DiffMismatched
+}
+
+func lookupHeader(headers Headers, name string) ([]string, bool) {
+	values := headers.Values(name)
+	return values, values != nil
+}
+
+func lookupCookie(cookies Cookies, name string) ([]string, bool) {
+	values, ok := cookies[name]
+	return values, ok
+}
+
+func lookupParam(params Params, name string) ([]string, bool) {
+	param, ok := params[name]
+	return param.Values, ok
+}
+
+var lineBreaks = strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ")
+
+func oneLine(value string) string {
+	return lineBreaks.Replace(value)
+}
@@ -0,0 +1,152 @@
+package journal
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNearMiss_Diff(t *testing.T) {
+	var response FindNearMissesResponse
+	err := json.Unmarshal([]byte(`{"nearMisses": [{
+		"request": {
+			"url": "/example?firstName=Jack",
+			"method": "GET",
+			"headers": {"X-Session": "abcfingerprintdef"},
+			"queryParams": {"firstName": {"key": "firstName", "values": ["Jack"]}},
+			"body": "{\"meta\": \"information\"}"
+		},
+		"stubMapping": {
+			"id": "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7",
+			"name": "Example",
+			"request": {
+				"urlPath": "/example",
+				"method": "POST",
+				"headers": {
+					"x-session": {"matches": "^\\S+fingerprint\\S+$"},
+					"x-absent": {"absent": true}
+				},
+				"queryParameters": {"firstName": {"equalTo": "john", "caseInsensitive": true}},
+				"bodyPatterns": [
+					{"equalToJson": "{\"meta\": \"information\"}"},
+					{"matchesXPath": "/meta"}
+				]
+			}
+		},
+		"matchResult": {"distance": 0.25}
+	}]}`), &response)
+	if err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+
+	nearMiss := response.NearMisses[0]
+	expected := []FieldDiff{
+		{Field: "method", Expected: "POST", Actual: "GET", Status: DiffMismatched},
+		{Field: "urlPath", Expected: "equalTo /example", Actual: "/example", Status: DiffMatched},
+		{Field: "header x-absent", Expected: "absent", Actual: "", Status: DiffMatched},
+		{Field: "header x-session", Expected: `matches ^\S+fingerprint\S+$`, Actual: "abcfingerprintdef", Status: DiffMatched},
+		{Field: "query firstName", Expected: "caseInsensitive, equalTo john", Actual: "Jack", Status: DiffMismatched},
+		{Field: "body", Expected: `equalToJson {"meta": "information"}`, Actual: `{"meta": "information"}`, Status: DiffMatched},
+		{Field: "body", Expected: "matchesXPath /meta", Actual: `{"meta": "information"}`, Status: DiffUnknown},
+	}
+
+	if diff := nearMiss.Diff(); !reflect.DeepEqual(expected, diff) {
+		t.Errorf("expected diff:\n%v\nactual diff:\n%v", expected, diff)
+	}
+
+	formatted := nearMiss.Format()
+	for _, line := range []string{
+		`Stub "Example" (8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7), distance 0.2500`,
+		"method",
+		"<<<<< does not match",
+		"(not evaluated)",
+	} {
+		if !strings.Contains(formatted, line) {
+			t.Errorf("expected formatted diff to contain %q, got:\n%s", line, formatted)
+		}
+	}
+}
+
+func TestNearMiss_Diff_Base64Body(t *testing.T) {
+	nearMiss := NearMiss{
+		Request: Request{Method: "POST", URL: "/upload", BodyAsBase64: "aGVsbG8gd29ybGQ="},
+		RequestPattern: &StubMappingRequest{
+			Method:       "POST",
+			BodyPatterns: []Matcher{{"contains": "world"}},
+		},
+	}
+
+	expected := []FieldDiff{
+		{Field: "method", Expected: "POST", Actual: "POST", Status: DiffMatched},
+		{Field: "body", Expected: "contains world", Actual: "hello world", Status: DiffMatched},
+	}
+
+	if diff := nearMiss.Diff(); !reflect.DeepEqual(expected, diff) {
+		t.Errorf("expected diff:\n%v\nactual diff:\n%v", expected, diff)
+	}
+}
+
+func TestNearMiss_Diff_RequestTarget(t *testing.T) {
+	request := Request{
+		Method:  "GET",
+		URL:     "/users/42",
+		Scheme:  "https",
+		Host:    "api.example.com",
+		Port:    443,
+		Headers: Headers{"Authorization": {"Basic dXNlcjpzZWNyZXQ="}},
+	}
+
+	testCases := []struct {
+		name     string
+		pattern  StubMappingRequest
+		expected []FieldDiff
+	}{
+		{
+			name: "host",
+			pattern: StubMappingRequest{
+				URLPath: "/users/42",
+				Host:    Matcher{"equalTo": "other.example.com"},
+			},
+			expected: []FieldDiff{
+				{Field: "urlPath", Expected: "equalTo /users/42", Actual: "/users/42", Status: DiffMatched},
+				{Field: "host", Expected: "equalTo other.example.com", Actual: "api.example.com", Status: DiffMismatched},
+			},
+		},
+		{
+			name: "path parameter",
+			pattern: StubMappingRequest{
+				URLPathTemplate: "/users/{id}",
+				PathParameters:  map[string]Matcher{"id": {"equalTo": "7"}},
+			},
+			expected: []FieldDiff{
+				{Field: "urlPathTemplate", Expected: "/users/{id}", Actual: "/users/42", Status: DiffMatched},
+				{Field: "path id", Expected: "equalTo 7", Actual: "42", Status: DiffMismatched},
+			},
+		},
+		{
+			name: "scheme, port and credentials",
+			pattern: StubMappingRequest{
+				Scheme:               "http",
+				Port:                 443,
+				BasicAuthCredentials: &BasicAuthCredentials{Username: "user", Password: "other"},
+				MultipartPatterns:    []MultipartPattern{{MatchingType: "ANY"}},
+			},
+			expected: []FieldDiff{
+				{Field: "scheme", Expected: "http", Actual: "https", Status: DiffMismatched},
+				{Field: "port", Expected: "443", Actual: "443", Status: DiffMatched},
+				{Field: "basicAuth", Expected: "user", Actual: "user", Status: DiffMismatched},
+				{Field: "multipart", Expected: `{"matchingType":"ANY"}`, Status: DiffUnknown},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nearMiss := NearMiss{Request: request, RequestPattern: &tc.pattern}
+			if diff := nearMiss.Diff(); !reflect.DeepEqual(tc.expected, diff) {
+				t.Errorf("expected diff:\n%v\nactual diff:\n%v", tc.expected, diff)
+			}
+		})
+	}
+}
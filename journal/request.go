@@ -0,0 +1,159 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *Request) UnmarshalJSON(data []byte) error {
+	type request Request
+	jsonRequest := struct {
+		*request
+		LoggedDate int64 `json:"loggedDate,omitempty"`
+	}{request: (*request)(r)}
+
+	if err := json.Unmarshal(data, &jsonRequest); err != nil {
+		return err
+	}
+
+	r.LoggedDate = time.Time{}
+	if jsonRequest.LoggedDate != 0 {
+		r.LoggedDate = time.UnixMilli(jsonRequest.LoggedDate)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Request) MarshalJSON() ([]byte, error) {
+	type request Request
+	jsonRequest := struct {
+		request
+		LoggedDate int64 `json:"loggedDate,omitempty"`
+	}{request: request(r)}
+
+	if !r.LoggedDate.IsZero() {
+		jsonRequest.LoggedDate = r.LoggedDate.UnixMilli()
+	}
+
+	return json.Marshal(jsonRequest)
+}
+
+// Header returns all values of the header, the name is case-insensitive.
+func (r Request) Header(name string) []string {
+	return r.Headers.Values(name)
+}
+
+// BodyBytes returns the body of the request, decoding it from base64 when WireMock logged it so.
+func (r Request) BodyBytes() ([]byte, error) {
+	return decodeBody(r.Body, r.BodyAsBase64)
+}
+
+// DecodeJSON unmarshals the JSON body of the request into v.
+func (r Request) DecodeJSON(v any) error {
+	body, err := r.BodyBytes()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// ToHTTPRequest rebuilds the logged request as *http.Request, e.g. to replay it against another server.
+func (r Request) ToHTTPRequest() (*http.Request, error) {
+	body, err := r.BodyBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	target := r.AbsoluteURL
+	if target == "" {
+		target = r.URL
+	}
+
+	req, err := http.NewRequest(r.Method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build request error: %w", err)
+	}
+
+	for name, values := range r.Headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	if req.Header.Get("Cookie") == "" {
+		for name, values := range r.Cookies {
+			for _, value := range values {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+		}
+	}
+
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+
+	return req, nil
+}
+
+// BodyBytes returns the body of the response, decoding it from base64 when WireMock logged it so.
+func (r Response) BodyBytes() ([]byte, error) {
+	return decodeBody(r.Body, r.BodyAsBase64)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Timing) UnmarshalJSON(data []byte) error {
+	var jsonTiming jsonTiming
+	if err := json.Unmarshal(data, &jsonTiming); err != nil {
+		return err
+	}
+
+	*t = Timing{
+		ServeTime:        time.Duration(jsonTiming.ServeTime) * time.Millisecond,
+		TotalTime:        time.Duration(jsonTiming.TotalTime) * time.Millisecond,
+		ProcessTime:      time.Duration(jsonTiming.ProcessTime) * time.Millisecond,
+		ResponseSendTime: time.Duration(jsonTiming.ResponseSendTime) * time.Millisecond,
+		AddedDelay:       time.Duration(jsonTiming.AddedDelay) * time.Millisecond,
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Timing) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonTiming{
+		ServeTime:        t.ServeTime.Milliseconds(),
+		TotalTime:        t.TotalTime.Milliseconds(),
+		ProcessTime:      t.ProcessTime.Milliseconds(),
+		ResponseSendTime: t.ResponseSendTime.Milliseconds(),
+		AddedDelay:       t.AddedDelay.Milliseconds(),
+	})
+}
+
+// jsonTiming is Timing as logged by WireMock, in milliseconds.
+type jsonTiming struct {
+	ServeTime        int64 `json:"serveTime,omitempty"`
+	TotalTime        int64 `json:"totalTime,omitempty"`
+	ProcessTime      int64 `json:"processTime,omitempty"`
+	ResponseSendTime int64 `json:"responseSendTime,omitempty"`
+	AddedDelay       int64 `json:"addedDelay,omitempty"`
+}
+
+func decodeBody(body, bodyAsBase64 string) ([]byte, error) {
+	if bodyAsBase64 == "" {
+		return []byte(body), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(bodyAsBase64)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64 body error: %w", err)
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,114 @@
+package journal
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const serveEventJSON = `{
+	"id": "45760a03-eebb-4387-ad0d-bb89b5d3d662",
+	"request": {
+		"url": "/orders?id=1",
+		"absoluteUrl": "http://localhost:8080/orders?id=1",
+		"method": "POST",
+		"headers": {
+			"Host": "localhost:8080",
+			"Content-Type": "application/json",
+			"Accept": ["text/plain", "application/json"]
+		},
+		"cookies": {"session": "abc", "tracking": ["1", "2"]},
+		"loggedDate": 1700000000123,
+		"bodyAsBase64": "eyJpZCI6IDF9",
+		"body": "{\"id\": 1}"
+	},
+	"response": {"status": 200, "headers": {"Content-Type": "text/plain"}, "bodyAsBase64": "b2s="},
+	"timing": {"addedDelay": 10, "processTime": 2, "responseSendTime": 1, "serveTime": 3, "totalTime": 13}
+}`
+
+func TestGetRequestResponse_UnmarshalJSON(t *testing.T) {
+	var event GetRequestResponse
+	if err := json.Unmarshal([]byte(serveEventJSON), &event); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+
+	request := event.Request
+	assertDeepEqual(t, []string{"text/plain", "application/json"}, request.Header("accept"))
+	assertDeepEqual(t, []string{"application/json"}, request.Header("Content-Type"))
+	assertDeepEqual(t, "1", request.Cookies.Get("tracking"))
+	assertDeepEqual(t, time.UnixMilli(1700000000123), request.LoggedDate)
+
+	assertDeepEqual(t, Timing{
+		ServeTime:        3 * time.Millisecond,
+		TotalTime:        13 * time.Millisecond,
+		ProcessTime:      2 * time.Millisecond,
+		ResponseSendTime: time.Millisecond,
+		AddedDelay:       10 * time.Millisecond,
+	}, event.Timing)
+
+	var body struct {
+		ID int `json:"id"`
+	}
+	if err := request.DecodeJSON(&body); err != nil {
+		t.Fatalf("DecodeJSON error: %v", err)
+	}
+	assertDeepEqual(t, 1, body.ID)
+
+	responseBody, err := event.Response.BodyBytes()
+	if err != nil {
+		t.Fatalf("BodyBytes error: %v", err)
+	}
+	assertDeepEqual(t, "ok", string(responseBody))
+}
+
+func TestGetRequestResponse_RoundTrip(t *testing.T) {
+	var event GetRequestResponse
+	if err := json.Unmarshal([]byte(serveEventJSON), &event); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+
+	var actual GetRequestResponse
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+
+	assertDeepEqual(t, event, actual)
+}
+
+func TestRequest_ToHTTPRequest(t *testing.T) {
+	var event GetRequestResponse
+	if err := json.Unmarshal([]byte(serveEventJSON), &event); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+
+	req, err := event.Request.ToHTTPRequest()
+	if err != nil {
+		t.Fatalf("ToHTTPRequest error: %v", err)
+	}
+
+	assertDeepEqual(t, "POST", req.Method)
+	assertDeepEqual(t, "http://localhost:8080/orders?id=1", req.URL.String())
+	assertDeepEqual(t, "localhost:8080", req.Host)
+	assertDeepEqual(t, []string{"text/plain", "application/json"}, req.Header.Values("Accept"))
+	assertDeepEqual(t, 3, len(req.Cookies()))
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("io.ReadAll error: %v", err)
+	}
+	assertDeepEqual(t, `{"id": 1}`, string(body))
+}
+
+func assertDeepEqual[T any](t *testing.T, expected, actual T) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
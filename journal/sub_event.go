@@ -0,0 +1,83 @@
+package journal
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Types of sub-events logged by WireMock while serving a request.
+const (
+	SubEventRequestNotMatched = "REQUEST_NOT_MATCHED"
+	SubEventInfo              = "INFO"
+	SubEventWarning           = "WARNING"
+	SubEventError             = "ERROR"
+	SubEventJSONError         = "JSON_ERROR"
+	SubEventXMLError          = "XML_ERROR"
+	SubEventWebhookRequest    = "WEBHOOK_REQUEST"
+	SubEventWebhookResponse   = "WEBHOOK_RESPONSE"
+)
+
+// SubEvent is an event logged by WireMock while serving a request, e.g. a template error or an outcome of a webhook.
+type SubEvent struct {
+	Type       string                 `json:"type,omitempty"`
+	TimeOffset time.Duration          `json:"timeOffsetNanos,omitempty"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+}
+
+// Message returns the message of INFO, WARNING and ERROR sub-events.
+func (e SubEvent) Message() string {
+	message, _ := e.Data["message"].(string)
+	return message
+}
+
+// DecodeData unmarshals the data of the sub-event into v,
+// e.g. journal.Request for WEBHOOK_REQUEST or journal.Response for WEBHOOK_RESPONSE.
+func (e SubEvent) DecodeData(v any) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// SubEventsOfType returns the sub-events of the given type.
+func (r GetRequestResponse) SubEventsOfType(eventType string) []SubEvent {
+	return subEventsOfType(r.SubEvents, eventType)
+}
+
+// Errors returns the sub-events reporting errors, e.g. failed response templates.
+func (r GetRequestResponse) Errors() []SubEvent {
+	return errorSubEvents(r.SubEvents)
+}
+
+// SubEventsOfType returns the sub-events of the given type.
+func (e ServeEvent) SubEventsOfType(eventType string) []SubEvent {
+	return subEventsOfType(e.SubEvents, eventType)
+}
+
+// Errors returns the sub-events reporting errors, e.g. failed response templates.
+func (e ServeEvent) Errors() []SubEvent {
+	return errorSubEvents(e.SubEvents)
+}
+
+func subEventsOfType(subEvents []SubEvent, eventType string) []SubEvent {
+	var result []SubEvent
+	for _, subEvent := range subEvents {
+		if subEvent.Type == eventType {
+			result = append(result, subEvent)
+		}
+	}
+	return result
+}
+
+func errorSubEvents(subEvents []SubEvent) []SubEvent {
+	var result []SubEvent
+	for _, subEvent := range subEvents {
+		switch subEvent.Type {
+		case SubEventError, SubEventJSONError, SubEventXMLError:
+			result = append(result, subEvent)
+		}
+	}
+	return result
+}
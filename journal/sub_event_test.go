@@ -0,0 +1,58 @@
+package journal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGetRequestResponse_SubEvents(t *testing.T) {
+	var event GetRequestResponse
+	err := json.Unmarshal([]byte(`{
+		"id": "45760a03-eebb-4387-ad0d-bb89b5d3d662",
+		"request": {"url": "/orders", "method": "POST"},
+		"stubMapping": {
+			"id": "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7",
+			"request": {"urlPath": "/orders", "method": "POST", "headers": {"X-Session": {"matches": ".+"}}},
+			"response": {"status": 201, "fixedDelayMilliseconds": 100, "transformers": ["response-template"]},
+			"serveEventListeners": [{"name": "webhook", "parameters": {"url": "http://localhost/callback"}}]
+		},
+		"responseDefinition": {"status": 201, "fixedDelayMilliseconds": 100},
+		"postServeActions": [{"name": "webhook", "parameters": {"url": "http://localhost/callback"}}],
+		"subEvents": [
+			{"type": "ERROR", "timeOffsetNanos": 1500, "data": {"message": "Template error"}},
+			{"type": "WEBHOOK_REQUEST", "timeOffsetNanos": 2000, "data": {"url": "/callback", "method": "POST", "headers": {"Accept": ["a", "b"]}}},
+			{"type": "WEBHOOK_RESPONSE", "timeOffsetNanos": 3000, "data": {"status": 200, "body": "ok"}}
+		]
+	}`), &event)
+	if err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+
+	errs := event.Errors()
+	assertDeepEqual(t, 1, len(errs))
+	assertDeepEqual(t, "Template error", errs[0].Message())
+	assertDeepEqual(t, 1500*time.Nanosecond, errs[0].TimeOffset)
+
+	webhookRequests := event.SubEventsOfType(SubEventWebhookRequest)
+	assertDeepEqual(t, 1, len(webhookRequests))
+
+	var webhookRequest Request
+	if err := webhookRequests[0].DecodeData(&webhookRequest); err != nil {
+		t.Fatalf("DecodeData error: %v", err)
+	}
+	assertDeepEqual(t, "/callback", webhookRequest.URL)
+	assertDeepEqual(t, []string{"a", "b"}, webhookRequest.Header("accept"))
+
+	var webhookResponse Response
+	if err := event.SubEventsOfType(SubEventWebhookResponse)[0].DecodeData(&webhookResponse); err != nil {
+		t.Fatalf("DecodeData error: %v", err)
+	}
+	assertDeepEqual(t, int64(200), webhookResponse.Status)
+
+	assertDeepEqual(t, "webhook", event.PostServeActions[0].Name)
+	assertDeepEqual(t, "webhook", event.StubMapping.ServeEventListeners[0].Name)
+	assertDeepEqual(t, "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7", event.StubMapping.ID)
+	assertDeepEqual(t, ".+", event.StubMapping.Request.Headers["X-Session"]["matches"])
+	assertDeepEqual(t, int64(100), event.ResponseDefinition.FixedDelayMilliseconds)
+}
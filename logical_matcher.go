@@ -2,6 +2,7 @@ package wiremock
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 type LogicalMatcher struct {
@@ -27,6 +28,46 @@ func (m LogicalMatcher) ParseMatcher() map[string]interface{} {
 	}
 }
 
+// UnmarshalJSON parses the JSON encoding of the matcher.
+func (m *LogicalMatcher) UnmarshalJSON(data []byte) error {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		return err
+	}
+
+	if len(jsonMap) != 1 {
+		return fmt.Errorf("logical matcher must have exactly one operator, got %d", len(jsonMap))
+	}
+
+	for operator, data := range jsonMap {
+		switch operator {
+		case "not":
+			operand, err := unmarshalBasicParamMatcher(data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", operator, err)
+			}
+			m.operands = []BasicParamMatcher{operand}
+		case "and", "or":
+			var jsonOperands []json.RawMessage
+			if err := json.Unmarshal(data, &jsonOperands); err != nil {
+				return fmt.Errorf("%s: %w", operator, err)
+			}
+
+			operands, err := unmarshalBasicParamMatcherList(jsonOperands)
+			if err != nil {
+				return fmt.Errorf("%s: %w", operator, err)
+			}
+			m.operands = operands
+		default:
+			return fmt.Errorf("unknown logical operator: %s", operator)
+		}
+
+		m.operator = operator
+	}
+
+	return nil
+}
+
 // Or returns a logical OR of the current matcher and the given matcher.
 func (m LogicalMatcher) Or(matcher BasicParamMatcher) BasicParamMatcher {
 	if m.operator == "or" {
@@ -0,0 +1,188 @@
+package wiremock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	mappingsDirName = "mappings"
+	filesDirName    = "__files"
+)
+
+// LoadMappings reads stub mappings from dir laid out as a WireMock root directory.
+// Every JSON file under dir/mappings may contain a single mapping or a {"mappings": [...]} list.
+// Body files found under dir/__files are inlined into the responses, so the stubs can be
+// registered on a server which doesn't have them.
+func LoadMappings(fsys fs.FS, dir string) ([]*StubRule, error) {
+	mappingsDir := path.Join(dir, mappingsDirName)
+	filesDir := path.Join(dir, filesDirName)
+
+	var stubs []*StubRule
+	err := fs.WalkDir(fsys, mappingsDir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.EqualFold(path.Ext(name), ".json") {
+			return nil
+		}
+
+		fileStubs, err := loadMappingFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("load mapping %s: %w", name, err)
+		}
+
+		for _, stub := range fileStubs {
+			if err := inlineBodyFile(fsys, filesDir, stub); err != nil {
+				return fmt.Errorf("load mapping %s: %w", name, err)
+			}
+		}
+
+		stubs = append(stubs, fileStubs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stubs, nil
+}
+
+func loadMappingFile(fsys fs.FS, name string) ([]*StubRule, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var mappings struct {
+		Mappings []*StubRule `json:"mappings"`
+	}
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, err
+	}
+
+	if mappings.Mappings != nil {
+		return mappings.Mappings, nil
+	}
+
+	var stub StubRule
+	if err := json.Unmarshal(data, &stub); err != nil {
+		return nil, err
+	}
+
+	return []*StubRule{&stub}, nil
+}
+
+// inlineBodyFile replaces the body file of the stub response with its content, if the file exists in filesDir.
+func inlineBodyFile(fsys fs.FS, filesDir string, stub *StubRule) error {
+	response, ok := stub.response.(Response)
+	if !ok || response.bodyFileName == nil {
+		return nil
+	}
+
+	name, err := bodyFilePath(*response.bodyFileName)
+	if err != nil {
+		return err
+	}
+
+	body, err := fs.ReadFile(fsys, path.Join(filesDir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read body file: %w", err)
+	}
+
+	response.bodyFileName = nil
+	if utf8.Valid(body) {
+		response = response.WithBody(string(body))
+	} else {
+		response = response.WithBinaryBody(body)
+	}
+
+	stub.response = response
+	return nil
+}
+
+// SaveMappings writes stubs to dir in WireMock's on-disk layout, one mapping file per stub under dir/mappings.
+// Body files referenced by the stubs are copied from bodyFiles to dir/__files when bodyFiles is not nil,
+// the content set by Response.WithUploadedBodyFile is always written.
+func SaveMappings(dir string, stubs []*StubRule, bodyFiles fs.FS) error {
+	mappingsDir := filepath.Join(dir, mappingsDirName)
+	if err := os.MkdirAll(mappingsDir, 0o755); err != nil {
+		return fmt.Errorf("save mappings: %w", err)
+	}
+
+	for _, stub := range stubs {
+		data, err := json.MarshalIndent(stub, "", "  ")
+		if err != nil {
+			return fmt.Errorf("save mappings: build %s error: %w", stub.UUID(), err)
+		}
+
+		err = os.WriteFile(filepath.Join(mappingsDir, stub.UUID()+".json"), data, 0o644)
+		if err != nil {
+			return fmt.Errorf("save mappings: %w", err)
+		}
+
+		if err := copyBodyFile(bodyFiles, filepath.Join(dir, filesDirName), stub); err != nil {
+			return fmt.Errorf("save mappings: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// copyBodyFile copies the body file of the stub response from bodyFiles to filesDir, if bodyFiles is set and the file exists.
+// The content set by Response.WithUploadedBodyFile is written instead of the file from bodyFiles.
+func copyBodyFile(bodyFiles fs.FS, filesDir string, stub *StubRule) error {
+	response, ok := stub.response.(Response)
+	if !ok || response.bodyFileName == nil {
+		return nil
+	}
+
+	name, err := bodyFilePath(*response.bodyFileName)
+	if err != nil {
+		return err
+	}
+
+	body := response.uploadedBodyFile
+	if body == nil {
+		if bodyFiles == nil {
+			return nil
+		}
+
+		body, err = fs.ReadFile(bodyFiles, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read body file: %w", err)
+		}
+	}
+
+	target := filepath.Join(filesDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(target, body, 0o644)
+}
+
+// bodyFilePath returns the slash-separated path of the body file relative to __files,
+// names which are absolute or point outside of __files are rejected.
+func bodyFilePath(name string) (string, error) {
+	cleaned := path.Clean(filepath.ToSlash(name))
+	if filepath.IsAbs(name) || filepath.VolumeName(name) != "" || cleaned == "." || !fs.ValidPath(cleaned) {
+		return "", fmt.Errorf("invalid body file name %q", name)
+	}
+
+	return cleaned, nil
+}
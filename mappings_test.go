@@ -0,0 +1,161 @@
+package wiremock
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMappings(t *testing.T) {
+	fsys := fstest.MapFS{
+		"root/mappings/single.json": {Data: []byte(`{
+			"id": "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7",
+			"request": {"method": "GET", "urlPath": "/single"},
+			"response": {"status": 200, "bodyFileName": "single.json"}
+		}`)},
+		"root/mappings/nested/list.json": {Data: []byte(`{"mappings": [
+			{"request": {"method": "GET", "urlPath": "/first"}, "response": {"status": 201}},
+			{"request": {"method": "GET", "urlPath": "/second"}, "response": {"bodyFileName": "missing.bin"}}
+		]}`)},
+		"root/mappings/README.md":  {Data: []byte(`not a mapping`)},
+		"root/__files/single.json": {Data: []byte(`{"name": "single"}`)},
+	}
+
+	stubs, err := LoadMappings(fsys, "root")
+	if err != nil {
+		t.Fatalf("LoadMappings error: %v", err)
+	}
+
+	if len(stubs) != 3 {
+		t.Fatalf("expected 3 stubs, got %d", len(stubs))
+	}
+
+	first := stubs[0].Response().ParseResponse()
+	if first["status"] != int64(http.StatusCreated) {
+		t.Errorf("expected first stub from nested/list.json, got %v", first)
+	}
+
+	second := stubs[1].Response().ParseResponse()
+	if second["bodyFileName"] != "missing.bin" {
+		t.Errorf("expected missing body file to be kept, got %v", second)
+	}
+
+	single := stubs[2].Response().ParseResponse()
+	if stubs[2].UUID() != "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7" {
+		t.Errorf("expected stub id to be kept, got %s", stubs[2].UUID())
+	}
+	if single["body"] != `{"name": "single"}` || single["bodyFileName"] != nil {
+		t.Errorf("expected body file to be inlined, got %v", single)
+	}
+}
+
+func TestSaveMappings(t *testing.T) {
+	dir := t.TempDir()
+	bodyFiles := fstest.MapFS{
+		"bodies/data.bin": {Data: []byte{0x00, 0xff}},
+	}
+
+	stubs := []*StubRule{
+		Get(URLPathEqualTo("/body")).WillReturnResponse(NewResponse().WithBody("body")),
+		Get(URLPathEqualTo("/file")).WillReturnResponse(NewResponse().WithBodyFile("bodies/data.bin")),
+		Get(URLPathEqualTo("/uploaded")).WillReturnResponse(NewResponse().WithUploadedBodyFile("bodies/uploaded.txt", []byte("uploaded"))),
+	}
+
+	err := SaveMappings(dir, stubs, bodyFiles)
+	if err != nil {
+		t.Fatalf("SaveMappings error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, filesDirName, "bodies", "data.bin"))
+	if err != nil {
+		t.Fatalf("expected body file to be copied: %v", err)
+	}
+	if string(data) != string([]byte{0x00, 0xff}) {
+		t.Errorf("unexpected body file content: %v", data)
+	}
+
+	loaded, err := LoadMappings(os.DirFS(dir), ".")
+	if err != nil {
+		t.Fatalf("LoadMappings error: %v", err)
+	}
+
+	if len(loaded) != len(stubs) {
+		t.Fatalf("expected %d stubs, got %d", len(stubs), len(loaded))
+	}
+
+	for _, stub := range loaded {
+		response := stub.Response().ParseResponse()
+		switch stub.UUID() {
+		case stubs[0].UUID():
+			if response["body"] != "body" {
+				t.Errorf("unexpected response: %v", response)
+			}
+		case stubs[1].UUID():
+			if string(response["base64Body"].([]byte)) != string([]byte{0x00, 0xff}) {
+				t.Errorf("expected binary body file to be inlined, got %v", response)
+			}
+		case stubs[2].UUID():
+			if response["body"] != "uploaded" {
+				t.Errorf("expected uploaded body file to be written, got %v", response)
+			}
+		default:
+			t.Errorf("unexpected stub %s", stub.UUID())
+		}
+	}
+}
+
+func TestMappings_InvalidBodyFileName(t *testing.T) {
+	for _, name := range []string{"../secret.json", "bodies/../../secret.json", "/etc/passwd"} {
+		t.Run(name, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"root/mappings/escape.json": {Data: []byte(`{
+					"request": {"method": "GET", "urlPath": "/escape"},
+					"response": {"status": 200, "bodyFileName": "` + name + `"}
+				}`)},
+				"secret.json": {Data: []byte(`secret`)},
+			}
+
+			if _, err := LoadMappings(fsys, "root"); err == nil {
+				t.Error("expected LoadMappings error, got none")
+			}
+
+			dir := t.TempDir()
+			stubs := []*StubRule{
+				Get(URLPathEqualTo("/escape")).WillReturnResponse(NewResponse().WithUploadedBodyFile(name, []byte("escaped"))),
+			}
+			if err := SaveMappings(filepath.Join(dir, "root"), stubs, fstest.MapFS{}); err == nil {
+				t.Error("expected SaveMappings error, got none")
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, "secret.json")); !os.IsNotExist(err) {
+				t.Errorf("expected no file written outside of the directory, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSaveMappings_UploadedBodyFileWithoutBodyFiles(t *testing.T) {
+	dir := t.TempDir()
+	stubs := []*StubRule{
+		Get(URLPathEqualTo("/uploaded")).WillReturnResponse(NewResponse().WithUploadedBodyFile("uploaded.txt", []byte("uploaded"))),
+		Get(URLPathEqualTo("/file")).WillReturnResponse(NewResponse().WithBodyFile("bodies/data.bin")),
+	}
+
+	if err := SaveMappings(dir, stubs, nil); err != nil {
+		t.Fatalf("SaveMappings error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, filesDirName, "uploaded.txt"))
+	if err != nil {
+		t.Fatalf("expected uploaded body file to be written: %v", err)
+	}
+	if string(data) != "uploaded" {
+		t.Errorf("unexpected body file content: %s", data)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, filesDirName, "bodies", "data.bin")); !os.IsNotExist(err) {
+		t.Errorf("expected body file not to be copied without bodyFiles, got %v", err)
+	}
+}
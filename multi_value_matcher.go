@@ -1,6 +1,9 @@
 package wiremock
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 type MultiValueMatcher struct {
 	strategy MultiValueMatchingStrategy
@@ -19,6 +22,36 @@ func (m MultiValueMatcher) ParseMatcher() map[string]interface{} {
 	}
 }
 
+// UnmarshalJSON parses the JSON encoding of the matcher.
+func (m *MultiValueMatcher) UnmarshalJSON(data []byte) error {
+	var jsonMap map[string][]json.RawMessage
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		return err
+	}
+
+	if len(jsonMap) != 1 {
+		return fmt.Errorf("multi value matcher must have exactly one strategy, got %d", len(jsonMap))
+	}
+
+	for strategy, jsonMatchers := range jsonMap {
+		switch MultiValueMatchingStrategy(strategy) {
+		case ParamHasExactly, ParamIncludes:
+		default:
+			return fmt.Errorf("unknown multi value strategy: %s", strategy)
+		}
+
+		matchers, err := unmarshalBasicParamMatcherList(jsonMatchers)
+		if err != nil {
+			return fmt.Errorf("%s: %w", strategy, err)
+		}
+
+		m.strategy = MultiValueMatchingStrategy(strategy)
+		m.matchers = matchers
+	}
+
+	return nil
+}
+
 // HasExactly returns a matcher that matches when the parameter has exactly the specified values.
 func HasExactly(matchers ...BasicParamMatcher) MultiValueMatcher {
 	return MultiValueMatcher{
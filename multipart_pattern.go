@@ -72,6 +72,37 @@ func (m *MultipartPattern) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.ParseMultipartPattern())
 }
 
+// UnmarshalJSON parses the JSON encoding of the pattern.
+func (m *MultipartPattern) UnmarshalJSON(data []byte) error {
+	var jsonPattern struct {
+		MatchingType MultipartMatchingType      `json:"matchingType"`
+		Headers      map[string]json.RawMessage `json:"headers"`
+		BodyPatterns []json.RawMessage          `json:"bodyPatterns"`
+	}
+	if err := json.Unmarshal(data, &jsonPattern); err != nil {
+		return err
+	}
+
+	headers, err := unmarshalMatchers(jsonPattern.Headers)
+	if err != nil {
+		return fmt.Errorf("headers: %w", err)
+	}
+
+	bodyPatterns, err := unmarshalBasicParamMatcherList(jsonPattern.BodyPatterns)
+	if err != nil {
+		return fmt.Errorf("bodyPatterns: %w", err)
+	}
+
+	m.matchingType = jsonPattern.MatchingType
+	if m.matchingType == "" {
+		m.matchingType = MultipartMatchingTypeAny
+	}
+	m.headers = headers
+	m.bodyPatterns = bodyPatterns
+
+	return nil
+}
+
 func (m *MultipartPattern) ParseMultipartPattern() map[string]interface{} {
 	multipart := map[string]interface{}{
 		"matchingType": m.matchingType,
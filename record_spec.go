@@ -0,0 +1,247 @@
+package wiremock
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Statuses of the recording.
+const (
+	RecordingStatusNeverStarted RecordingStatus = "NeverStarted"
+	RecordingStatusRecording    RecordingStatus = "Recording"
+	RecordingStatusStopped      RecordingStatus = "Stopped"
+)
+
+// RecordingStatus is enum of the recording states of WireMock.
+type RecordingStatus string
+
+// Matchers of the request bodies of the recorded stubs.
+const (
+	RecordBodyMatcherAuto        RecordBodyMatcher = "auto"
+	RecordBodyMatcherEqualTo     RecordBodyMatcher = "equalTo"
+	RecordBodyMatcherEqualToJson RecordBodyMatcher = "equalToJson"
+	RecordBodyMatcherEqualToXml  RecordBodyMatcher = "equalToXml"
+)
+
+// RecordBodyMatcher is enum of the matchers generated for the request bodies of the recorded stubs.
+type RecordBodyMatcher string
+
+// RecordSpec configures the stubs generated by recordings and snapshots.
+type RecordSpec struct {
+	targetBaseURL         string
+	filters               *Request
+	ids                   []string
+	allowNonProxied       *bool
+	captureHeaders        map[string]bool
+	requestBodyPattern    map[string]interface{}
+	extractBodyCriteria   map[string]string
+	persist               *bool
+	repeatsAsScenarios    *bool
+	transformers          []string
+	transformerParameters map[string]interface{}
+}
+
+// NewRecordSpec returns a new *RecordSpec.
+func NewRecordSpec() *RecordSpec {
+	return &RecordSpec{}
+}
+
+// ForTarget sets the base URL of the recorded service and returns *RecordSpec.
+func (s *RecordSpec) ForTarget(targetBaseURL string) *RecordSpec {
+	s.targetBaseURL = targetBaseURL
+	return s
+}
+
+// OnlyRequestsMatching records only the requests matching r and returns *RecordSpec.
+func (s *RecordSpec) OnlyRequestsMatching(r *Request) *RecordSpec {
+	s.filters = r
+	return s
+}
+
+// OnlyRequestIDs snapshots only the requests of the journal with the given IDs and returns *RecordSpec.
+func (s *RecordSpec) OnlyRequestIDs(ids ...string) *RecordSpec {
+	s.ids = ids
+	return s
+}
+
+// AllowNonProxied snapshots also the requests not proxied by WireMock and returns *RecordSpec.
+func (s *RecordSpec) AllowNonProxied(allow bool) *RecordSpec {
+	s.allowNonProxied = &allow
+	return s
+}
+
+// CaptureHeader adds a header matched by the recorded stubs and returns *RecordSpec.
+func (s *RecordSpec) CaptureHeader(header string, caseInsensitive bool) *RecordSpec {
+	if s.captureHeaders == nil {
+		s.captureHeaders = make(map[string]bool)
+	}
+
+	s.captureHeaders[header] = caseInsensitive
+
+	return s
+}
+
+// MatchRequestBodyWithEqualToJson matches the request bodies of the recorded stubs with equalToJson and returns *RecordSpec.
+func (s *RecordSpec) MatchRequestBodyWithEqualToJson(ignoreArrayOrder, ignoreExtraElements bool) *RecordSpec {
+	s.requestBodyPattern = map[string]interface{}{
+		"matcher":             RecordBodyMatcherEqualToJson,
+		"ignoreArrayOrder":    ignoreArrayOrder,
+		"ignoreExtraElements": ignoreExtraElements,
+	}
+	return s
+}
+
+// MatchRequestBodyWithEqualToXml matches the request bodies of the recorded stubs with equalToXml and returns *RecordSpec.
+func (s *RecordSpec) MatchRequestBodyWithEqualToXml() *RecordSpec {
+	s.requestBodyPattern = map[string]interface{}{
+		"matcher": RecordBodyMatcherEqualToXml,
+	}
+	return s
+}
+
+// MatchRequestBodyWithEqualTo matches the request bodies of the recorded stubs with equalTo and returns *RecordSpec.
+func (s *RecordSpec) MatchRequestBodyWithEqualTo(caseInsensitive bool) *RecordSpec {
+	s.requestBodyPattern = map[string]interface{}{
+		"matcher":         RecordBodyMatcherEqualTo,
+		"caseInsensitive": caseInsensitive,
+	}
+	return s
+}
+
+// ChooseBodyMatchTypeAutomatically lets WireMock choose the matcher by the content type of the request bodies and returns *RecordSpec.
+func (s *RecordSpec) ChooseBodyMatchTypeAutomatically(ignoreArrayOrder, ignoreExtraElements, caseInsensitive bool) *RecordSpec {
+	s.requestBodyPattern = map[string]interface{}{
+		"matcher":             RecordBodyMatcherAuto,
+		"ignoreArrayOrder":    ignoreArrayOrder,
+		"ignoreExtraElements": ignoreExtraElements,
+		"caseInsensitive":     caseInsensitive,
+	}
+	return s
+}
+
+// ExtractTextBodiesOver saves text response bodies larger than size bytes to files and returns *RecordSpec.
+func (s *RecordSpec) ExtractTextBodiesOver(size int64) *RecordSpec {
+	return s.withExtractBodyCriteria("textSizeThreshold", size)
+}
+
+// ExtractBinaryBodiesOver saves binary response bodies larger than size bytes to files and returns *RecordSpec.
+func (s *RecordSpec) ExtractBinaryBodiesOver(size int64) *RecordSpec {
+	return s.withExtractBodyCriteria("binarySizeThreshold", size)
+}
+
+func (s *RecordSpec) withExtractBodyCriteria(key string, size int64) *RecordSpec {
+	if s.extractBodyCriteria == nil {
+		s.extractBodyCriteria = make(map[string]string)
+	}
+
+	s.extractBodyCriteria[key] = strconv.FormatInt(size, 10)
+
+	return s
+}
+
+// MakeStubsPersistent sets whether the recorded stubs are saved to the mappings directory and returns *RecordSpec.
+func (s *RecordSpec) MakeStubsPersistent(persist bool) *RecordSpec {
+	s.persist = &persist
+	return s
+}
+
+// RepeatsAsScenarios sets whether repeated requests are recorded as scenarios, otherwise only the first one is recorded,
+// and returns *RecordSpec.
+func (s *RecordSpec) RepeatsAsScenarios(repeatsAsScenarios bool) *RecordSpec {
+	s.repeatsAsScenarios = &repeatsAsScenarios
+	return s
+}
+
+// WithTransformers sets the stub mapping transformers applied to the recorded stubs and returns *RecordSpec.
+func (s *RecordSpec) WithTransformers(transformers ...string) *RecordSpec {
+	s.transformers = transformers
+	return s
+}
+
+// WithTransformerParameters sets the parameters of the stub mapping transformers and returns *RecordSpec.
+func (s *RecordSpec) WithTransformerParameters(parameters map[string]interface{}) *RecordSpec {
+	s.transformerParameters = parameters
+	return s
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s *RecordSpec) MarshalJSON() ([]byte, error) {
+	jsonSpec := map[string]interface{}{}
+
+	if s.targetBaseURL != "" {
+		jsonSpec["targetBaseUrl"] = s.targetBaseURL
+	}
+
+	filters, err := s.parseFilters()
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) > 0 {
+		jsonSpec["filters"] = filters
+	}
+
+	if s.captureHeaders != nil {
+		captureHeaders := make(map[string]interface{}, len(s.captureHeaders))
+		for header, caseInsensitive := range s.captureHeaders {
+			captureHeader := map[string]interface{}{}
+			if caseInsensitive {
+				captureHeader["caseInsensitive"] = true
+			}
+			captureHeaders[header] = captureHeader
+		}
+		jsonSpec["captureHeaders"] = captureHeaders
+	}
+
+	if s.requestBodyPattern != nil {
+		jsonSpec["requestBodyPattern"] = s.requestBodyPattern
+	}
+
+	if s.extractBodyCriteria != nil {
+		jsonSpec["extractBodyCriteria"] = s.extractBodyCriteria
+	}
+
+	if s.persist != nil {
+		jsonSpec["persist"] = *s.persist
+	}
+
+	if s.repeatsAsScenarios != nil {
+		jsonSpec["repeatsAsScenarios"] = *s.repeatsAsScenarios
+	}
+
+	if s.transformers != nil {
+		jsonSpec["transformers"] = s.transformers
+	}
+
+	if s.transformerParameters != nil {
+		jsonSpec["transformerParameters"] = s.transformerParameters
+	}
+
+	return json.Marshal(jsonSpec)
+}
+
+// parseFilters returns the request pattern of the filters extended with the IDs and the non-proxied flag.
+func (s *RecordSpec) parseFilters() (map[string]interface{}, error) {
+	filters := map[string]interface{}{}
+
+	if s.filters != nil {
+		rawRequest, err := s.filters.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("filters: %w", err)
+		}
+
+		if err := json.Unmarshal(rawRequest, &filters); err != nil {
+			return nil, fmt.Errorf("filters: %w", err)
+		}
+	}
+
+	if s.ids != nil {
+		filters["ids"] = s.ids
+	}
+
+	if s.allowNonProxied != nil {
+		filters["allowNonProxied"] = *s.allowNonProxied
+	}
+
+	return filters, nil
+}
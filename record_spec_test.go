@@ -0,0 +1,75 @@
+package wiremock
+
+import (
+	"testing"
+)
+
+func TestRecordSpec_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     *RecordSpec
+		expected string
+	}{
+		{
+			name:     "target",
+			spec:     NewRecordSpec().ForTarget(`http://example.com/"quoted"`),
+			expected: `{"targetBaseUrl": "http://example.com/\"quoted\""}`,
+		},
+		{
+			name: "full",
+			spec: NewRecordSpec().
+				ForTarget("http://example.com").
+				OnlyRequestsMatching(NewRequest("GET", URLPathMatching("/api/.*")).
+					WithHeader("Accept", EqualTo("application/json"))).
+				OnlyRequestIDs("40a93c4a-d378-4e07-8321-6158d5dbcb29").
+				AllowNonProxied(true).
+				CaptureHeader("Accept", false).
+				CaptureHeader("Content-Type", true).
+				MatchRequestBodyWithEqualToJson(false, true).
+				ExtractTextBodiesOver(2048).
+				ExtractBinaryBodiesOver(10240).
+				MakeStubsPersistent(false).
+				RepeatsAsScenarios(true).
+				WithTransformers("modify-response-header").
+				WithTransformerParameters(map[string]interface{}{"headerValue": "123"}),
+			expected: `{
+				"targetBaseUrl": "http://example.com",
+				"filters": {
+					"method": "GET",
+					"urlPathPattern": "/api/.*",
+					"headers": {"Accept": {"equalTo": "application/json"}},
+					"ids": ["40a93c4a-d378-4e07-8321-6158d5dbcb29"],
+					"allowNonProxied": true
+				},
+				"captureHeaders": {
+					"Accept": {},
+					"Content-Type": {"caseInsensitive": true}
+				},
+				"requestBodyPattern": {
+					"matcher": "equalToJson",
+					"ignoreArrayOrder": false,
+					"ignoreExtraElements": true
+				},
+				"extractBodyCriteria": {
+					"textSizeThreshold": "2048",
+					"binarySizeThreshold": "10240"
+				},
+				"persist": false,
+				"repeatsAsScenarios": true,
+				"transformers": ["modify-response-header"],
+				"transformerParameters": {"headerValue": "123"}
+			}`,
+		},
+		{
+			name:     "empty",
+			spec:     NewRecordSpec(),
+			expected: `{}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertJSONEqual(t, []byte(tc.expected), tc.spec)
+		})
+	}
+}
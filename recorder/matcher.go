@@ -0,0 +1,61 @@
+package recorder
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// Matcher adds the matchers of the recorded request to the stub.
+type Matcher func(stub *wiremock.StubRule, req *http.Request, body []byte)
+
+// DefaultMatchers match the method, the URL path, the query and the JSON body of the recorded requests.
+var DefaultMatchers = []Matcher{MatchMethod, MatchURLPath, MatchQuery, MatchJSONBody}
+
+// MatchMethod matches the method of the recorded request.
+func MatchMethod(stub *wiremock.StubRule, req *http.Request, _ []byte) {
+	stub.Request().WithMethod(req.Method)
+}
+
+// MatchURLPath matches the URL path of the recorded request with URLPathEqualTo.
+func MatchURLPath(stub *wiremock.StubRule, req *http.Request, _ []byte) {
+	stub.Request().WithURLMatched(wiremock.URLPathEqualTo(req.URL.Path))
+}
+
+// MatchQuery matches every query parameter of the recorded request with EqualTo.
+func MatchQuery(stub *wiremock.StubRule, req *http.Request, _ []byte) {
+	for name, values := range req.URL.Query() {
+		if len(values) == 1 {
+			stub.WithQueryParam(name, wiremock.EqualTo(values[0]))
+			continue
+		}
+
+		matchers := make([]wiremock.BasicParamMatcher, len(values))
+		for i, value := range slices.Sorted(slices.Values(values)) {
+			matchers[i] = wiremock.EqualTo(value)
+		}
+		stub.WithQueryParam(name, wiremock.HasExactly(matchers...))
+	}
+}
+
+// MatchJSONBody matches the JSON body of the recorded request with EqualToJson, other bodies are not matched.
+func MatchJSONBody(stub *wiremock.StubRule, _ *http.Request, body []byte) {
+	if len(body) == 0 || !json.Valid(body) {
+		return
+	}
+
+	stub.WithBodyPattern(wiremock.EqualToJson(string(body)))
+}
+
+// MatchHeaders returns a matcher matching the given headers of the recorded request with EqualTo.
+func MatchHeaders(headers ...string) Matcher {
+	return func(stub *wiremock.StubRule, req *http.Request, _ []byte) {
+		for _, header := range headers {
+			if value := req.Header.Get(header); value != "" {
+				stub.WithHeader(header, wiremock.EqualTo(value))
+			}
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Package recorder records HTTP exchanges of Go clients as WireMock stubs and replays them.
+//
+// In record mode the Recorder forwards requests to the real service and captures every exchange as a stub,
+// saved as mapping JSON files by Stop. In replay mode the saved stubs are registered with WireMock
+// and the requests are rewritten to the WireMock URL:
+//
+//	rec, err := recorder.New(recorder.ModeReplay, "testdata/github",
+//		recorder.WithClient(wiremockClient, wiremockURL))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	t.Cleanup(func() { _ = rec.Stop() })
+//
+//	httpClient := &http.Client{Transport: rec}
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+// Modes of the Recorder.
+const (
+	ModeRecord Mode = "record"
+	ModeReplay Mode = "replay"
+)
+
+// Mode is enum of whether the Recorder records or replays the exchanges.
+type Mode string
+
+// skippedResponseHeaders are computed by WireMock when the stub is served.
+var skippedResponseHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Transfer-Encoding": true,
+	"Connection":        true,
+}
+
+// Recorder is an http.RoundTripper recording or replaying the exchanges.
+type Recorder struct {
+	mode        Mode
+	dir         string
+	transport   http.RoundTripper
+	matchers    []Matcher
+	client      *wiremock.Client
+	wiremockURL *url.URL
+
+	mu    sync.Mutex
+	stubs []*wiremock.StubRule
+}
+
+// Option configures the Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport sending the requests, http.DefaultTransport is used by default.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithMatchers sets the matchers of the recorded stubs, DefaultMatchers are used by default.
+func WithMatchers(matchers ...Matcher) Option {
+	return func(r *Recorder) {
+		r.matchers = matchers
+	}
+}
+
+// WithClient sets the WireMock client registering the stubs and the URL the requests are rewritten to in replay mode.
+func WithClient(client *wiremock.Client, wiremockURL string) Option {
+	return func(r *Recorder) {
+		r.client = client
+		r.wiremockURL, _ = url.Parse(wiremockURL)
+	}
+}
+
+// New returns a Recorder saving the stubs to dir laid out as a WireMock root directory.
+// In replay mode the stubs are loaded from dir and registered with the client set by WithClient.
+func New(mode Mode, dir string, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		dir:       dir,
+		transport: http.DefaultTransport,
+		matchers:  DefaultMatchers,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	switch mode {
+	case ModeRecord:
+		return r, nil
+	case ModeReplay:
+		if r.client == nil || r.wiremockURL == nil || r.wiremockURL.Host == "" {
+			return nil, errors.New("recorder: replay mode requires WithClient with a valid WireMock URL")
+		}
+
+		stubs, err := wiremock.LoadMappings(os.DirFS(dir), ".")
+		if err != nil {
+			return nil, fmt.Errorf("recorder: %w", err)
+		}
+
+		err = r.client.ImportStubs(context.Background(), stubs, wiremock.ImportOptions{DuplicatePolicy: wiremock.DuplicatePolicyOverwrite})
+		if err != nil {
+			return nil, fmt.Errorf("recorder: %w", err)
+		}
+
+		r.stubs = stubs
+		return r, nil
+	default:
+		return nil, fmt.Errorf("recorder: unknown mode %q", mode)
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	return r.record(req)
+}
+
+// Stubs returns the recorded or the replayed stubs.
+func (r *Recorder) Stubs() []*wiremock.StubRule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stubs := make([]*wiremock.StubRule, len(r.stubs))
+	copy(stubs, r.stubs)
+	return stubs
+}
+
+// Stop saves the recorded stubs as mapping JSON files in record mode
+// and removes the replayed stubs from WireMock in replay mode.
+func (r *Recorder) Stop() error {
+	stubs := r.Stubs()
+
+	if r.mode == ModeRecord {
+		if err := wiremock.SaveMappings(r.dir, stubs, nil); err != nil {
+			return fmt.Errorf("recorder: %w", err)
+		}
+		return nil
+	}
+
+	for _, stub := range stubs {
+		if err := r.client.DeleteStubByID(stub.UUID()); err != nil && !errors.Is(err, wiremock.ErrStubNotFound) {
+			return fmt.Errorf("recorder: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	body, req, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("recorder: read response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	stub := wiremock.NewStubRule("ANY", wiremock.URLMatching(".*")).
+		WithName(fmt.Sprintf("%s %s", req.Method, req.URL.Path))
+	for _, matcher := range r.matchers {
+		matcher(stub, req, body)
+	}
+	stub.WillReturnResponse(recordedResponse(res, responseBody))
+
+	r.mu.Lock()
+	r.stubs = append(r.stubs, stub)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Scheme = r.wiremockURL.Scheme
+	rewritten.URL.Host = r.wiremockURL.Host
+	rewritten.Host = ""
+
+	return r.transport.RoundTrip(rewritten)
+}
+
+// readRequestBody reads the body of the request and returns a copy of the request with the body restored.
+func readRequestBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("recorder: read request body: %w", err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return body, req, nil
+}
+
+// recordedResponse returns the response served on replay, repeated headers such as Set-Cookie are kept as separate values.
+func recordedResponse(res *http.Response, body []byte) wiremock.Response {
+	response := wiremock.NewResponse().
+		WithStatus(int64(res.StatusCode)).
+		WithHeaders(map[string]string{})
+	for name, values := range res.Header {
+		if skippedResponseHeaders[name] {
+			continue
+		}
+		response = response.WithHeaderValues(name, values...)
+	}
+
+	switch {
+	case len(body) == 0:
+		return response
+	case utf8.Valid(body):
+		return response.WithBody(string(body))
+	default:
+		return response.WithBinaryBody(body)
+	}
+}
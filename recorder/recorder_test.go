@@ -0,0 +1,142 @@
+package recorder
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestRecorder_Record(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Set-Cookie", "session=abc; Path=/")
+		w.Header().Add("Set-Cookie", "theme=dark; Expires=Wed, 21 Oct 2026 07:28:00 GMT")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"path": "` + r.URL.Path + `", "request": ` + string(body) + `}`))
+	}))
+	defer backend.Close()
+
+	dir := t.TempDir()
+	rec, err := New(ModeRecord, dir)
+	requireNoError(t, err)
+
+	client := &http.Client{Transport: rec}
+	res, err := client.Post(backend.URL+"/users?team=a", "application/json", strings.NewReader(`{"name": "john"}`))
+	requireNoError(t, err)
+
+	body, err := io.ReadAll(res.Body)
+	requireNoError(t, err)
+	_ = res.Body.Close()
+	assertEqual(t, `{"path": "/users", "request": {"name": "john"}}`, string(body))
+
+	requireNoError(t, rec.Stop())
+
+	stubs := rec.Stubs()
+	assertEqual(t, 1, len(stubs))
+
+	data, err := os.ReadFile(filepath.Join(dir, "mappings", stubs[0].UUID()+".json"))
+	requireNoError(t, err)
+
+	var mapping map[string]interface{}
+	requireNoError(t, json.Unmarshal(data, &mapping))
+
+	expected := map[string]interface{}{
+		"method":          "POST",
+		"urlPath":         "/users",
+		"queryParameters": map[string]interface{}{"team": map[string]interface{}{"equalTo": "a"}},
+		"bodyPatterns":    []interface{}{map[string]interface{}{"equalToJson": `{"name": "john"}`}},
+	}
+	assertEqual(t, expected, mapping["request"].(map[string]interface{}))
+
+	response := mapping["response"].(map[string]interface{})
+	assertEqual(t, float64(http.StatusCreated), response["status"].(float64))
+	assertEqual(t, string(body), response["body"].(string))
+	headers := response["headers"].(map[string]interface{})
+	if _, ok := headers["Content-Length"]; ok {
+		t.Error("expected Content-Length not to be recorded")
+	}
+	assertEqual[interface{}](t, []interface{}{"session=abc; Path=/", "theme=dark; Expires=Wed, 21 Oct 2026 07:28:00 GMT"}, headers["Set-Cookie"])
+
+	loaded, err := wiremock.LoadMappings(os.DirFS(dir), ".")
+	requireNoError(t, err)
+	assertEqual(t, 1, len(loaded))
+}
+
+func TestRecorder_Replay(t *testing.T) {
+	dir := t.TempDir()
+	stub := wiremock.Get(wiremock.URLPathEqualTo("/users")).
+		WillReturnResponse(wiremock.NewResponse().WithBody("replayed"))
+	requireNoError(t, wiremock.SaveMappings(dir, []*wiremock.StubRule{stub}, nil))
+
+	var (
+		mu       sync.Mutex
+		imported []byte
+		deleted  []string
+		served   []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/__admin/mappings/import":
+			imported, _ = io.ReadAll(r.Body)
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+		default:
+			served = append(served, r.URL.RequestURI())
+			_, _ = w.Write([]byte("replayed"))
+		}
+	}))
+	defer server.Close()
+
+	rec, err := New(ModeReplay, dir, WithClient(wiremock.NewClient(server.URL), server.URL))
+	requireNoError(t, err)
+
+	if !strings.Contains(string(imported), stub.UUID()) {
+		t.Errorf("expected stub %s to be imported, got %s", stub.UUID(), imported)
+	}
+
+	client := &http.Client{Transport: rec}
+	res, err := client.Get("https://api.example.com/users?page=2")
+	requireNoError(t, err)
+
+	body, err := io.ReadAll(res.Body)
+	requireNoError(t, err)
+	_ = res.Body.Close()
+	assertEqual(t, "replayed", string(body))
+	assertEqual(t, []string{"/users?page=2"}, served)
+
+	requireNoError(t, rec.Stop())
+	assertEqual(t, []string{"/__admin/mappings/" + stub.UUID()}, deleted)
+}
+
+func TestNew_ReplayWithoutClient(t *testing.T) {
+	if _, err := New(ModeReplay, t.TempDir()); err == nil {
+		t.Error("expected error without client")
+	}
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertEqual[T any](t *testing.T, expected, actual T) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
@@ -2,6 +2,7 @@ package wiremock
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 // A Request is the part of StubRule describing the matching of the http request
@@ -138,8 +139,11 @@ func (r *Request) WithCookie(cookie string, matcher BasicParamMatcher) *Request
 // MarshalJSON gives valid JSON or error.
 func (r *Request) MarshalJSON() ([]byte, error) {
 	request := map[string]interface{}{
-		"method":                        r.method,
-		string(r.urlMatcher.Strategy()): r.urlMatcher.Value(),
+		"method": r.method,
+	}
+
+	if r.urlMatcher != nil {
+		request[string(r.urlMatcher.Strategy())] = r.urlMatcher.Value()
 	}
 
 	if r.scheme != nil {
@@ -185,3 +189,89 @@ func (r *Request) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(request)
 }
+
+// UnmarshalJSON parses the JSON encoding of the request.
+// The method defaults to ANY when not set.
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var jsonRequest struct {
+		Method               string                     `json:"method"`
+		Scheme               *string                    `json:"scheme"`
+		Host                 json.RawMessage            `json:"host"`
+		Port                 *int64                     `json:"port"`
+		Headers              map[string]json.RawMessage `json:"headers"`
+		QueryParameters      map[string]json.RawMessage `json:"queryParameters"`
+		PathParameters       map[string]json.RawMessage `json:"pathParameters"`
+		Cookies              map[string]json.RawMessage `json:"cookies"`
+		FormParameters       map[string]json.RawMessage `json:"formParameters"`
+		BodyPatterns         []json.RawMessage          `json:"bodyPatterns"`
+		MultipartPatterns    []*MultipartPattern        `json:"multipartPatterns"`
+		BasicAuthCredentials *struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"basicAuthCredentials"`
+	}
+	if err := json.Unmarshal(data, &jsonRequest); err != nil {
+		return err
+	}
+
+	var jsonURL map[string]json.RawMessage
+	if err := json.Unmarshal(data, &jsonURL); err != nil {
+		return err
+	}
+
+	*r = Request{method: jsonRequest.Method}
+	if r.method == "" {
+		r.method = "ANY"
+	}
+
+	for _, strategy := range []URLMatchingStrategy{URLEqualToRule, URLPathEqualToRule, URLPathMatchingRule, URLMatchingRule, URLPathTemplateRule} {
+		if value, ok := jsonURL[string(strategy)]; ok {
+			var url string
+			if err := json.Unmarshal(value, &url); err != nil {
+				return fmt.Errorf("%s: %w", strategy, err)
+			}
+			r.urlMatcher = URLMatcher{strategy: strategy, value: url}
+		}
+	}
+
+	r.scheme = jsonRequest.Scheme
+	r.port = jsonRequest.Port
+
+	if len(jsonRequest.Host) > 0 {
+		host, err := unmarshalBasicParamMatcher(jsonRequest.Host)
+		if err != nil {
+			return fmt.Errorf("host: %w", err)
+		}
+		r.host = host
+	}
+
+	var err error
+	if r.headers, err = unmarshalMatchers(jsonRequest.Headers); err != nil {
+		return fmt.Errorf("headers: %w", err)
+	}
+	if r.queryParams, err = unmarshalMatchers(jsonRequest.QueryParameters); err != nil {
+		return fmt.Errorf("queryParameters: %w", err)
+	}
+	if r.pathParams, err = unmarshalMatchers(jsonRequest.PathParameters); err != nil {
+		return fmt.Errorf("pathParameters: %w", err)
+	}
+	if r.cookies, err = unmarshalBasicParamMatchers(jsonRequest.Cookies); err != nil {
+		return fmt.Errorf("cookies: %w", err)
+	}
+	if r.formParameters, err = unmarshalBasicParamMatchers(jsonRequest.FormParameters); err != nil {
+		return fmt.Errorf("formParameters: %w", err)
+	}
+	if r.bodyPatterns, err = unmarshalBasicParamMatcherList(jsonRequest.BodyPatterns); err != nil {
+		return fmt.Errorf("bodyPatterns: %w", err)
+	}
+
+	for _, pattern := range jsonRequest.MultipartPatterns {
+		r.multipartPatterns = append(r.multipartPatterns, pattern)
+	}
+
+	if jsonRequest.BasicAuthCredentials != nil {
+		r.WithBasicAuth(jsonRequest.BasicAuthCredentials.Username, jsonRequest.BasicAuthCredentials.Password)
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package wiremock
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -14,6 +16,11 @@ const (
 	FaultConnectionResetByPeer  Fault = "CONNECTION_RESET_BY_PEER"
 )
 
+// ParseResponse returns the response failing with the fault, so faults can be used as responses.
+func (f Fault) ParseResponse() map[string]interface{} {
+	return NewResponse().WithFault(f).ParseResponse()
+}
+
 type ResponseInterface interface {
 	ParseResponse() map[string]interface{}
 }
@@ -22,14 +29,20 @@ type Response struct {
 	body                  *string
 	base64Body            []byte
 	bodyFileName          *string
+	uploadedBodyFile      []byte
 	jsonBody              interface{}
-	headers               map[string]string
+	headers               map[string][]string
 	status                int64
 	delayDistribution     DelayInterface
 	chunkedDribbleDelay   *chunkedDribbleDelay
 	fault                 *Fault
 	transformers          []string
-	transformerParameters map[string]string
+	transformerParameters map[string]interface{}
+
+	proxyBaseURL                  *string
+	additionalProxyRequestHeaders map[string]string
+	removeProxyRequestHeaders     []string
+	proxyURLPrefixToRemove        *string
 }
 
 func NewResponse() Response {
@@ -84,18 +97,29 @@ func (r Response) WithStatus(status int64) Response {
 
 // WithHeader sets header for response
 func (r Response) WithHeader(key, value string) Response {
-	if r.headers == nil {
-		r.headers = make(map[string]string)
+	return r.WithHeaderValues(key, value)
+}
+
+// WithHeaderValues sets a header repeated with every value for response, e.g. Set-Cookie.
+func (r Response) WithHeaderValues(key string, values ...string) Response {
+	headers := make(map[string][]string, len(r.headers)+1)
+	for name, headerValues := range r.headers {
+		headers[name] = headerValues
 	}
 
-	r.headers[key] = value
+	headers[key] = values
+	r.headers = headers
 
 	return r
 }
 
 // WithHeaders sets headers for response
 func (r Response) WithHeaders(headers map[string]string) Response {
-	r.headers = headers
+	r.headers = make(map[string][]string, len(headers))
+	for key, value := range headers {
+		r.headers[key] = []string{value}
+	}
+
 	return r
 }
 
@@ -125,6 +149,14 @@ func (r Response) WithJSONBody(body interface{}) Response {
 // WithBodyFile sets body file name for response
 func (r Response) WithBodyFile(fileName string) Response {
 	r.bodyFileName = &fileName
+	r.uploadedBodyFile = nil
+	return r
+}
+
+// WithUploadedBodyFile sets body file name for response, the file is uploaded with the content when the stub is registered.
+func (r Response) WithUploadedBodyFile(fileName string, content []byte) Response {
+	r.bodyFileName = &fileName
+	r.uploadedBodyFile = content
 	return r
 }
 
@@ -137,7 +169,7 @@ func (r Response) WithTransformers(transformers ...string) Response {
 // WithTransformerParameter sets transformer parameters for response
 func (r Response) WithTransformerParameter(key, value string) Response {
 	if r.transformerParameters == nil {
-		r.transformerParameters = make(map[string]string)
+		r.transformerParameters = make(map[string]interface{})
 	}
 
 	r.transformerParameters[key] = value
@@ -147,7 +179,43 @@ func (r Response) WithTransformerParameter(key, value string) Response {
 
 // WithTransformerParameters sets transformer parameters for response
 func (r Response) WithTransformerParameters(transformerParameters map[string]string) Response {
-	r.transformerParameters = transformerParameters
+	r.transformerParameters = make(map[string]interface{}, len(transformerParameters))
+	for key, value := range transformerParameters {
+		r.transformerParameters[key] = value
+	}
+
+	return r
+}
+
+// ProxiedFrom makes WireMock forward the request to baseURL and return the response of the backend.
+func (r Response) ProxiedFrom(baseURL string) Response {
+	r.proxyBaseURL = &baseURL
+	return r
+}
+
+// WithAdditionalProxyRequestHeader sets a header added to the requests forwarded by the proxy.
+func (r Response) WithAdditionalProxyRequestHeader(key, value string) Response {
+	headers := make(map[string]string, len(r.additionalProxyRequestHeaders)+1)
+	for name, headerValue := range r.additionalProxyRequestHeaders {
+		headers[name] = headerValue
+	}
+
+	headers[key] = value
+	r.additionalProxyRequestHeaders = headers
+
+	return r
+}
+
+// WithRemoveProxyRequestHeaders sets headers removed from the requests forwarded by the proxy.
+func (r Response) WithRemoveProxyRequestHeaders(headers ...string) Response {
+	r.removeProxyRequestHeaders = headers
+	return r
+}
+
+// WithProxyURLPrefixToRemove sets the prefix removed from the URL of the requests forwarded by the proxy,
+// e.g. "/other/service" forwards /other/service/doc/123 as /doc/123.
+func (r Response) WithProxyURLPrefixToRemove(prefix string) Response {
+	r.proxyURLPrefixToRemove = &prefix
 	return r
 }
 
@@ -173,7 +241,7 @@ func (r Response) ParseResponse() map[string]interface{} {
 	}
 
 	if r.headers != nil {
-		jsonMap["headers"] = r.headers
+		jsonMap["headers"] = marshalHeaders(r.headers)
 	}
 
 	if r.delayDistribution != nil {
@@ -196,5 +264,121 @@ func (r Response) ParseResponse() map[string]interface{} {
 		jsonMap["transformerParameters"] = r.transformerParameters
 	}
 
+	if r.proxyBaseURL != nil {
+		jsonMap["proxyBaseUrl"] = *r.proxyBaseURL
+	}
+
+	if r.additionalProxyRequestHeaders != nil {
+		jsonMap["additionalProxyRequestHeaders"] = r.additionalProxyRequestHeaders
+	}
+
+	if r.removeProxyRequestHeaders != nil {
+		jsonMap["removeProxyRequestHeaders"] = r.removeProxyRequestHeaders
+	}
+
+	if r.proxyURLPrefixToRemove != nil {
+		jsonMap["proxyUrlPrefixToRemove"] = *r.proxyURLPrefixToRemove
+	}
+
 	return jsonMap
 }
+
+// UnmarshalJSON parses the JSON encoding of the response.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	var jsonResponse struct {
+		Status                 *int64                     `json:"status"`
+		Body                   *string                    `json:"body"`
+		Base64Body             []byte                     `json:"base64Body"`
+		BodyFileName           *string                    `json:"bodyFileName"`
+		JSONBody               interface{}                `json:"jsonBody"`
+		Headers                map[string]json.RawMessage `json:"headers"`
+		FixedDelayMilliseconds *int64                     `json:"fixedDelayMilliseconds"`
+		DelayDistribution      json.RawMessage            `json:"delayDistribution"`
+		ChunkedDribbleDelay    *chunkedDribbleDelay       `json:"chunkedDribbleDelay"`
+		Fault                  *Fault                     `json:"fault"`
+		Transformers           []string                   `json:"transformers"`
+		TransformerParameters  map[string]interface{}     `json:"transformerParameters"`
+
+		ProxyBaseURL                  *string           `json:"proxyBaseUrl"`
+		AdditionalProxyRequestHeaders map[string]string `json:"additionalProxyRequestHeaders"`
+		RemoveProxyRequestHeaders     []string          `json:"removeProxyRequestHeaders"`
+		ProxyURLPrefixToRemove        *string           `json:"proxyUrlPrefixToRemove"`
+	}
+	if err := json.Unmarshal(data, &jsonResponse); err != nil {
+		return err
+	}
+
+	*r = NewResponse()
+	if jsonResponse.Status != nil {
+		r.status = *jsonResponse.Status
+	}
+
+	r.body = jsonResponse.Body
+	r.base64Body = jsonResponse.Base64Body
+	r.bodyFileName = jsonResponse.BodyFileName
+	r.jsonBody = jsonResponse.JSONBody
+	headers, err := unmarshalHeaders(jsonResponse.Headers)
+	if err != nil {
+		return fmt.Errorf("headers: %w", err)
+	}
+	r.headers = headers
+	r.chunkedDribbleDelay = jsonResponse.ChunkedDribbleDelay
+	r.fault = jsonResponse.Fault
+	r.transformers = jsonResponse.Transformers
+	r.transformerParameters = jsonResponse.TransformerParameters
+	r.proxyBaseURL = jsonResponse.ProxyBaseURL
+	r.additionalProxyRequestHeaders = jsonResponse.AdditionalProxyRequestHeaders
+	r.removeProxyRequestHeaders = jsonResponse.RemoveProxyRequestHeaders
+	r.proxyURLPrefixToRemove = jsonResponse.ProxyURLPrefixToRemove
+
+	if jsonResponse.FixedDelayMilliseconds != nil {
+		r.delayDistribution = fixedDelay{milliseconds: *jsonResponse.FixedDelayMilliseconds}
+	}
+
+	if len(jsonResponse.DelayDistribution) > 0 && string(jsonResponse.DelayDistribution) != "null" {
+		delay, err := unmarshalDelay(jsonResponse.DelayDistribution)
+		if err != nil {
+			return fmt.Errorf("delayDistribution: %w", err)
+		}
+		r.delayDistribution = delay
+	}
+
+	return nil
+}
+
+// marshalHeaders returns a single header value as a string and repeated values as an array, as WireMock does.
+func marshalHeaders(headers map[string][]string) map[string]interface{} {
+	jsonHeaders := make(map[string]interface{}, len(headers))
+	for key, values := range headers {
+		if len(values) == 1 {
+			jsonHeaders[key] = values[0]
+		} else {
+			jsonHeaders[key] = values
+		}
+	}
+
+	return jsonHeaders
+}
+
+// unmarshalHeaders parses headers given as a string or an array of strings.
+func unmarshalHeaders(jsonHeaders map[string]json.RawMessage) (map[string][]string, error) {
+	if jsonHeaders == nil {
+		return nil, nil
+	}
+
+	headers := make(map[string][]string, len(jsonHeaders))
+	for key, data := range jsonHeaders {
+		if value, ok := stringValue(data); ok {
+			headers[key] = []string{value}
+			continue
+		}
+
+		var values []string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("%s must be a string or an array of strings", key)
+		}
+		headers[key] = values
+	}
+
+	return headers, nil
+}
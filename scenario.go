@@ -0,0 +1,189 @@
+package wiremock
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	uuidPkg "github.com/google/uuid"
+)
+
+// StateStarted is the state every scenario is in initially and after a reset.
+const StateStarted State = ScenarioStateStarted
+
+// State is a named state of a Scenario.
+type State string
+
+// Scenario groups stubs linked by scenario states.
+type Scenario struct {
+	name        string
+	stubs       []*StubRule
+	transitions []scenarioTransition
+}
+
+type scenarioTransition struct {
+	from State
+	to   State
+}
+
+// NewScenario returns a new *Scenario.
+func NewScenario(name string) *Scenario {
+	return &Scenario{name: name}
+}
+
+// Name is getter for name
+func (s *Scenario) Name() string {
+	return s.name
+}
+
+// Stubs returns the stubs of the scenario linked by their states.
+func (s *Scenario) Stubs() []*StubRule {
+	return s.stubs
+}
+
+// Step adds the stub served when the scenario is in state from, which moves the scenario to state to.
+func (s *Scenario) Step(from, to State, stub *StubRule) *Scenario {
+	stub.InScenario(s.name).
+		WhenScenarioStateIs(string(from)).
+		WillSetStateTo(string(to))
+
+	s.stubs = append(s.stubs, stub)
+	s.transitions = append(s.transitions, scenarioTransition{from: from, to: to})
+	return s
+}
+
+// In adds the stub served when the scenario is in state, without changing the state.
+func (s *Scenario) In(state State, stub *StubRule) *Scenario {
+	stub.InScenario(s.name).WhenScenarioStateIs(string(state))
+
+	s.stubs = append(s.stubs, stub)
+	s.transitions = append(s.transitions, scenarioTransition{from: state, to: state})
+	return s
+}
+
+// Sequence adds stubs served one after another, starting from StateStarted.
+// Once the sequence is exhausted, the last stub keeps being served.
+// The states of a sequence are always SequenceState(0) to SequenceState(n-1), so a scenario holds a single sequence
+// and Step or In can't serve the same request in StateStarted; Validate reports such conflicting stubs.
+func (s *Scenario) Sequence(stubs ...*StubRule) *Scenario {
+	for i, stub := range stubs {
+		if i == len(stubs)-1 {
+			s.In(SequenceState(i), stub)
+			continue
+		}
+
+		s.Step(SequenceState(i), SequenceState(i+1), stub)
+	}
+
+	return s
+}
+
+// Cycle makes the last added stub move the scenario back to StateStarted, so a sequence is served in a loop.
+func (s *Scenario) Cycle() *Scenario {
+	if len(s.stubs) == 0 {
+		return s
+	}
+
+	last := len(s.stubs) - 1
+	s.stubs[last].WillSetStateTo(string(StateStarted))
+	s.transitions[last].to = StateStarted
+	return s
+}
+
+// Sequence returns a scenario serving the responses one after another for the requests matching method and urlMatcher.
+// Once the responses are exhausted, the last one keeps being served, unless the scenario is cycled.
+// The scenario gets a unique name, so sequences for the same URL don't share their state.
+func Sequence(method string, urlMatcher URLMatcher, responses ...ResponseInterface) *Scenario {
+	stubs := make([]*StubRule, len(responses))
+	for i, response := range responses {
+		stubs[i] = NewStubRule(method, urlMatcher).WillReturnResponse(response)
+	}
+
+	uuid, _ := uuidPkg.NewRandom()
+	name := fmt.Sprintf("%s %s %s", method, urlMatcher.Value(), uuid.String())
+
+	return NewScenario(name).Sequence(stubs...)
+}
+
+// FailTimes returns n failure responses followed by the success response, to be served by Sequence.
+// A negative n is treated as 0.
+func FailTimes(n int, failure, success ResponseInterface) []ResponseInterface {
+	n = max(n, 0)
+	responses := make([]ResponseInterface, 0, n+1)
+	for range n {
+		responses = append(responses, failure)
+	}
+
+	return append(responses, success)
+}
+
+// SequenceState returns the state in which the stub at index i of a sequence is served.
+func SequenceState(i int) State {
+	if i == 0 {
+		return StateStarted
+	}
+
+	return State(fmt.Sprintf("Step %d", i+1))
+}
+
+// Validate checks that the scenario has stubs, every state they are served in can be reached from StateStarted
+// and no two stubs serve the same request in the same state, e.g. after calling Sequence twice.
+func (s *Scenario) Validate() error {
+	if len(s.stubs) == 0 {
+		return fmt.Errorf("scenario %q has no stubs", s.name)
+	}
+
+	if err := s.validateConflicts(); err != nil {
+		return err
+	}
+
+	reachable := map[State]bool{StateStarted: true}
+	for changed := true; changed; {
+		changed = false
+		for _, transition := range s.transitions {
+			if reachable[transition.from] && !reachable[transition.to] {
+				reachable[transition.to] = true
+				changed = true
+			}
+		}
+	}
+
+	var unreachable []string
+	for _, transition := range s.transitions {
+		if !reachable[transition.from] && !slices.Contains(unreachable, string(transition.from)) {
+			unreachable = append(unreachable, string(transition.from))
+		}
+	}
+
+	if len(unreachable) > 0 {
+		slices.Sort(unreachable)
+		return fmt.Errorf("scenario %q has unreachable states: %s", s.name, strings.Join(unreachable, ", "))
+	}
+
+	return nil
+}
+
+// validateConflicts checks that no two stubs with the same request and priority are served in the same state,
+// as WireMock would serve only one of them.
+func (s *Scenario) validateConflicts() error {
+	seen := make(map[string]bool, len(s.stubs))
+	for i, stub := range s.stubs {
+		request, err := stub.request.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("scenario %q: build stub %s error: %w", s.name, stub.UUID(), err)
+		}
+
+		var priority int64
+		if stub.priority != nil {
+			priority = *stub.priority
+		}
+
+		key := fmt.Sprintf("%s\x00%d\x00%s", s.transitions[i].from, priority, request)
+		if seen[key] {
+			return fmt.Errorf("scenario %q has conflicting stubs for the same request in state %s", s.name, s.transitions[i].from)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package wiremock
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestScenario_Step(t *testing.T) {
+	const (
+		paid    State = "Paid"
+		shipped State = "Shipped"
+	)
+
+	scenario := NewScenario("Order").
+		Step(StateStarted, paid, Post(URLPathEqualTo("/pay"))).
+		Step(paid, shipped, Post(URLPathEqualTo("/ship"))).
+		In(shipped, Get(URLPathEqualTo("/order")))
+
+	err := scenario.Validate()
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	expected := []struct {
+		required string
+		next     *string
+	}{
+		{required: "Started", next: ptr("Paid")},
+		{required: "Paid", next: ptr("Shipped")},
+		{required: "Shipped"},
+	}
+
+	stubs := scenario.Stubs()
+	if len(stubs) != len(expected) {
+		t.Fatalf("expected %d stubs, got %d", len(expected), len(stubs))
+	}
+
+	for i, stub := range stubs {
+		if *stub.scenarioName != "Order" {
+			t.Errorf("stub %d: expected scenario Order, got %s", i, *stub.scenarioName)
+		}
+		if *stub.requiredScenarioState != expected[i].required {
+			t.Errorf("stub %d: expected required state %s, got %s", i, expected[i].required, *stub.requiredScenarioState)
+		}
+		if (stub.newScenarioState == nil) != (expected[i].next == nil) ||
+			(stub.newScenarioState != nil && *stub.newScenarioState != *expected[i].next) {
+			t.Errorf("stub %d: expected new state %v, got %v", i, expected[i].next, stub.newScenarioState)
+		}
+	}
+}
+
+func TestScenario_Sequence(t *testing.T) {
+	scenario := NewScenario("Sequence").Sequence(
+		Get(URLPathEqualTo("/first")),
+		Get(URLPathEqualTo("/second")),
+		Get(URLPathEqualTo("/third")),
+	)
+
+	err := scenario.Validate()
+	if err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	stubs := scenario.Stubs()
+	for i, stub := range stubs {
+		if *stub.requiredScenarioState != string(SequenceState(i)) {
+			t.Errorf("stub %d: expected required state %s, got %s", i, SequenceState(i), *stub.requiredScenarioState)
+		}
+	}
+
+	if *stubs[1].newScenarioState != "Step 3" {
+		t.Errorf("expected second stub to move to Step 3, got %s", *stubs[1].newScenarioState)
+	}
+	if stubs[2].newScenarioState != nil {
+		t.Errorf("expected last stub to keep the state, got %s", *stubs[2].newScenarioState)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	t.Run("fail times", func(t *testing.T) {
+		failure := NewResponse().WithStatus(http.StatusServiceUnavailable)
+		scenario := Sequence(http.MethodGet, URLPathEqualTo("/retry"), FailTimes(2, failure, OK())...)
+
+		stubs := scenario.Stubs()
+		if len(stubs) != 3 {
+			t.Fatalf("expected 3 stubs, got %d", len(stubs))
+		}
+
+		expectedStatuses := []int64{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK}
+		for i, stub := range stubs {
+			if status := stub.Response().ParseResponse()["status"]; status != expectedStatuses[i] {
+				t.Errorf("stub %d: expected status %d, got %v", i, expectedStatuses[i], status)
+			}
+			if stub.Request().urlMatcher.Value() != "/retry" {
+				t.Errorf("stub %d: unexpected url %s", i, stub.Request().urlMatcher.Value())
+			}
+		}
+
+		if stubs[2].newScenarioState != nil {
+			t.Errorf("expected last stub to keep the state, got %s", *stubs[2].newScenarioState)
+		}
+	})
+
+	t.Run("faults and cycling", func(t *testing.T) {
+		scenario := Sequence(http.MethodGet, URLPathEqualTo("/flaky"), FaultConnectionResetByPeer, OK()).Cycle()
+
+		err := scenario.Validate()
+		if err != nil {
+			t.Fatalf("Validate error: %v", err)
+		}
+
+		stubs := scenario.Stubs()
+		if fault := stubs[0].Response().ParseResponse()["fault"]; fault != FaultConnectionResetByPeer {
+			t.Errorf("expected fault %s, got %v", FaultConnectionResetByPeer, fault)
+		}
+		if *stubs[1].newScenarioState != ScenarioStateStarted {
+			t.Errorf("expected last stub to move back to %s, got %s", ScenarioStateStarted, *stubs[1].newScenarioState)
+		}
+	})
+
+	t.Run("unique names", func(t *testing.T) {
+		first := Sequence(http.MethodGet, URLPathEqualTo("/same"), OK())
+		second := Sequence(http.MethodGet, URLPathEqualTo("/same"), OK())
+
+		if first.Name() == second.Name() {
+			t.Errorf("expected unique scenario names, got %s", first.Name())
+		}
+	})
+}
+
+func TestScenario_Validate(t *testing.T) {
+	t.Run("no stubs", func(t *testing.T) {
+		err := NewScenario("Empty").Validate()
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+	})
+
+	t.Run("unreachable states", func(t *testing.T) {
+		err := NewScenario("Typo").
+			Step(StateStarted, "Payed", Post(URLPathEqualTo("/pay"))).
+			Step("Paid", "Shipped", Post(URLPathEqualTo("/ship"))).
+			In("Shipped", Get(URLPathEqualTo("/order"))).
+			Validate()
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+
+		if !strings.Contains(err.Error(), "unreachable states: Paid, Shipped") {
+			t.Errorf("expected unreachable states in error, got %v", err)
+		}
+	})
+
+	t.Run("conflicting stubs", func(t *testing.T) {
+		err := NewScenario("Twice").
+			Sequence(Get(URLPathEqualTo("/status")), Get(URLPathEqualTo("/status"))).
+			Sequence(Get(URLPathEqualTo("/status"))).
+			Validate()
+		if err == nil {
+			t.Fatal("expected error, got none")
+		}
+
+		if !strings.Contains(err.Error(), "conflicting stubs for the same request in state Started") {
+			t.Errorf("expected conflicting stubs in error, got %v", err)
+		}
+	})
+
+	t.Run("different requests in the same state", func(t *testing.T) {
+		err := NewScenario("Order").
+			Sequence(Get(URLPathEqualTo("/status")), Get(URLPathEqualTo("/status"))).
+			In(StateStarted, Get(URLPathEqualTo("/order"))).
+			Validate()
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
+
+func TestFailTimes(t *testing.T) {
+	responses := FailTimes(-2, NewResponse().WithStatus(500), OK())
+	if len(responses) != 1 {
+		t.Errorf("expected only the success response, got %d responses", len(responses))
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,56 @@
+package wiremock
+
+import "encoding/json"
+
+// webhookExtensionName is the name of the WireMock extension sending webhooks.
+const webhookExtensionName = "webhook"
+
+// RequestPhase is a phase of serving a request in which a serve event listener is called.
+// Required wiremock >= 3.0.0
+type RequestPhase string
+
+// Request phases of serve event listeners.
+const (
+	RequestPhaseBeforeMatch        RequestPhase = "BEFORE_MATCH"
+	RequestPhaseAfterMatch         RequestPhase = "AFTER_MATCH"
+	RequestPhaseBeforeResponseSent RequestPhase = "BEFORE_RESPONSE_SENT"
+	RequestPhaseAfterComplete      RequestPhase = "AFTER_COMPLETE"
+)
+
+// serveEventListener is an extension called by WireMock in the given request phases.
+type serveEventListener struct {
+	name          string
+	requestPhases []RequestPhase
+	parameters    map[string]interface{}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (l serveEventListener) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonServeEventListener{
+		Name:          l.name,
+		RequestPhases: l.requestPhases,
+		Parameters:    l.parameters,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *serveEventListener) UnmarshalJSON(data []byte) error {
+	var jsonListener jsonServeEventListener
+	if err := json.Unmarshal(data, &jsonListener); err != nil {
+		return err
+	}
+
+	*l = serveEventListener{
+		name:          jsonListener.Name,
+		requestPhases: jsonListener.RequestPhases,
+		parameters:    jsonListener.Parameters,
+	}
+
+	return nil
+}
+
+type jsonServeEventListener struct {
+	Name          string                 `json:"name"`
+	RequestPhases []RequestPhase         `json:"requestPhases,omitempty"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"`
+}
@@ -0,0 +1,74 @@
+package wiremock
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Settings are the global settings of WireMock applied to every stub.
+type Settings struct {
+	// FixedDelay delays every response.
+	FixedDelay time.Duration
+	// DelayDistribution delays every response by a random delay, e.g. NewUniformRandomDelay.
+	DelayDistribution DelayInterface
+	// ProxyPassThrough forwards the requests of proxy stubs, WireMock enables it when nil.
+	ProxyPassThrough *bool
+	// Extended are the settings of the extensions.
+	Extended map[string]any
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s Settings) MarshalJSON() ([]byte, error) {
+	jsonSettings := map[string]interface{}{}
+
+	if s.FixedDelay > 0 {
+		jsonSettings["fixedDelay"] = s.FixedDelay.Milliseconds()
+	}
+
+	if s.DelayDistribution != nil {
+		jsonSettings["delayDistribution"] = s.DelayDistribution.ParseDelay()
+	}
+
+	if s.ProxyPassThrough != nil {
+		jsonSettings["proxyPassThrough"] = *s.ProxyPassThrough
+	}
+
+	if s.Extended != nil {
+		jsonSettings["extended"] = s.Extended
+	}
+
+	return json.Marshal(jsonSettings)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *Settings) UnmarshalJSON(data []byte) error {
+	var jsonSettings struct {
+		FixedDelay        *int64          `json:"fixedDelay"`
+		DelayDistribution json.RawMessage `json:"delayDistribution"`
+		ProxyPassThrough  *bool           `json:"proxyPassThrough"`
+		Extended          map[string]any  `json:"extended"`
+	}
+	if err := json.Unmarshal(data, &jsonSettings); err != nil {
+		return err
+	}
+
+	*s = Settings{
+		ProxyPassThrough: jsonSettings.ProxyPassThrough,
+		Extended:         jsonSettings.Extended,
+	}
+
+	if jsonSettings.FixedDelay != nil {
+		s.FixedDelay = time.Duration(*jsonSettings.FixedDelay) * time.Millisecond
+	}
+
+	if len(jsonSettings.DelayDistribution) > 0 && string(jsonSettings.DelayDistribution) != "null" {
+		delay, err := unmarshalDelay(jsonSettings.DelayDistribution)
+		if err != nil {
+			return fmt.Errorf("delayDistribution: %w", err)
+		}
+		s.DelayDistribution = delay
+	}
+
+	return nil
+}
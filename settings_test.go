@@ -0,0 +1,52 @@
+package wiremock
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSettings_MarshalJSON(t *testing.T) {
+	proxyPassThrough := false
+	settings := Settings{
+		FixedDelay:        500 * time.Millisecond,
+		DelayDistribution: NewLogNormalRandomDelay(90*time.Millisecond, 0.1),
+		ProxyPassThrough:  &proxyPassThrough,
+		Extended:          map[string]any{"chaos": map[string]any{"failureRate": 0.1}},
+	}
+
+	assertJSONEqual(t, []byte(`{
+		"fixedDelay": 500,
+		"delayDistribution": {"type": "lognormal", "median": 90, "sigma": 0.1},
+		"proxyPassThrough": false,
+		"extended": {"chaos": {"failureRate": 0.1}}
+	}`), settings)
+
+	assertJSONEqual(t, []byte(`{}`), Settings{})
+}
+
+func TestSettings_UnmarshalJSON(t *testing.T) {
+	var settings Settings
+	err := json.Unmarshal([]byte(`{
+		"fixedDelay": 500,
+		"delayDistribution": {"type": "uniform", "lower": 10, "upper": 20},
+		"proxyPassThrough": true,
+		"extended": {"key": "value"}
+	}`), &settings)
+	if err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+
+	proxyPassThrough := true
+	expected := Settings{
+		FixedDelay:        500 * time.Millisecond,
+		DelayDistribution: NewUniformRandomDelay(10*time.Millisecond, 20*time.Millisecond),
+		ProxyPassThrough:  &proxyPassThrough,
+		Extended:          map[string]any{"key": "value"},
+	}
+
+	if !reflect.DeepEqual(expected, settings) {
+		t.Errorf("expected %+v, got %+v", expected, settings)
+	}
+}
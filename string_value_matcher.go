@@ -3,7 +3,10 @@ package wiremock
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"strings"
 )
 
 type MatcherInterface interface {
@@ -18,10 +21,134 @@ type BasicParamMatcher interface {
 	And(stringMatcher BasicParamMatcher) BasicParamMatcher
 }
 
+// unmarshalMatcher parses the JSON encoding of any matcher, including multi value matchers.
+func unmarshalMatcher(data []byte) (MatcherInterface, error) {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		return nil, err
+	}
+
+	_, hasExactly := jsonMap[string(ParamHasExactly)]
+	_, includes := jsonMap[string(ParamIncludes)]
+	if hasExactly || includes {
+		var m MultiValueMatcher
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	return unmarshalBasicParamMatcher(data)
+}
+
+// unmarshalBasicParamMatcher parses the JSON encoding of a string value, JSON schema or logical matcher.
+func unmarshalBasicParamMatcher(data []byte) (BasicParamMatcher, error) {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		return nil, err
+	}
+
+	for _, operator := range []string{"and", "or", "not"} {
+		if _, ok := jsonMap[operator]; ok {
+			var m LogicalMatcher
+			if err := json.Unmarshal(data, &m); err != nil {
+				return nil, err
+			}
+			return m, nil
+		}
+	}
+
+	if _, ok := jsonMap[string(ParamMatchesJsonSchema)]; ok {
+		var m JSONSchemaMatcher
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	var m StringValueMatcher
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// unmarshalMatchers parses a JSON object of named matchers.
+func unmarshalMatchers(jsonMap map[string]json.RawMessage) (map[string]MatcherInterface, error) {
+	if jsonMap == nil {
+		return nil, nil
+	}
+
+	matchers := make(map[string]MatcherInterface, len(jsonMap))
+	for name, data := range jsonMap {
+		matcher, err := unmarshalMatcher(data)
+		if err != nil {
+			return nil, fmt.Errorf("matcher %q: %w", name, err)
+		}
+		matchers[name] = matcher
+	}
+
+	return matchers, nil
+}
+
+// unmarshalBasicParamMatchers parses a JSON object of named basic matchers.
+func unmarshalBasicParamMatchers(jsonMap map[string]json.RawMessage) (map[string]BasicParamMatcher, error) {
+	if jsonMap == nil {
+		return nil, nil
+	}
+
+	matchers := make(map[string]BasicParamMatcher, len(jsonMap))
+	for name, data := range jsonMap {
+		matcher, err := unmarshalBasicParamMatcher(data)
+		if err != nil {
+			return nil, fmt.Errorf("matcher %q: %w", name, err)
+		}
+		matchers[name] = matcher
+	}
+
+	return matchers, nil
+}
+
+// unmarshalBasicParamMatcherList parses a JSON array of basic matchers.
+func unmarshalBasicParamMatcherList(jsonList []json.RawMessage) ([]BasicParamMatcher, error) {
+	if jsonList == nil {
+		return nil, nil
+	}
+
+	matchers := make([]BasicParamMatcher, len(jsonList))
+	for i, data := range jsonList {
+		matcher, err := unmarshalBasicParamMatcher(data)
+		if err != nil {
+			return nil, fmt.Errorf("matcher %d: %w", i, err)
+		}
+		matchers[i] = matcher
+	}
+
+	return matchers, nil
+}
+
 type StringValueMatcher struct {
 	strategy ParamMatchingStrategy
 	value    string
 	flags    []string
+
+	// rawValue keeps a strategy value which isn't a string, e.g. matchesJsonPath with a sub-matcher.
+	rawValue json.RawMessage
+	// options keeps the other fields of the matcher, e.g. xPathNamespaces of matchesXPath.
+	options map[string]json.RawMessage
+}
+
+// stringValueStrategies are the strategies told apart from the options of a parsed matcher.
+var stringValueStrategies = map[ParamMatchingStrategy]bool{
+	ParamEqualTo:         true,
+	ParamMatches:         true,
+	ParamContains:        true,
+	ParamEqualToXml:      true,
+	ParamEqualToJson:     true,
+	ParamMatchesXPath:    true,
+	ParamMatchesJsonPath: true,
+	ParamDoesNotMatch:    true,
+	ParamDoesNotContains: true,
 }
 
 // MarshalJSON returns the JSON encoding of the matcher.
@@ -31,8 +158,11 @@ func (m StringValueMatcher) MarshalJSON() ([]byte, error) {
 
 // ParseMatcher returns the map representation of the structure.
 func (m StringValueMatcher) ParseMatcher() map[string]interface{} {
-	jsonMap := make(map[string]interface{}, 1+len(m.flags))
-	if m.strategy != "" {
+	jsonMap := make(map[string]interface{}, 1+len(m.flags)+len(m.options))
+	switch {
+	case m.rawValue != nil:
+		jsonMap[string(m.strategy)] = m.rawValue
+	case m.strategy != "":
 		jsonMap[string(m.strategy)] = m.value
 	}
 
@@ -40,9 +170,65 @@ func (m StringValueMatcher) ParseMatcher() map[string]interface{} {
 		jsonMap[flag] = true
 	}
 
+	for key, value := range m.options {
+		jsonMap[key] = value
+	}
+
 	return jsonMap
 }
 
+// UnmarshalJSON parses the JSON encoding of the matcher.
+// Boolean values are read as flags, JSON values of equalToJson are kept in their encoded form.
+// Other JSON values, e.g. matchesJsonPath with a sub-matcher, and options such as xPathNamespaces
+// are kept as they are, so the matcher is marshalled back unchanged.
+func (m *StringValueMatcher) UnmarshalJSON(data []byte) error {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		return err
+	}
+
+	*m = StringValueMatcher{}
+	var keys []string
+	for _, key := range slices.Sorted(maps.Keys(jsonMap)) {
+		var flag bool
+		if err := json.Unmarshal(jsonMap[key], &flag); err == nil {
+			if flag {
+				m.flags = append(m.flags, key)
+			}
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		if len(keys) > 1 && !stringValueStrategies[ParamMatchingStrategy(key)] {
+			if m.options == nil {
+				m.options = make(map[string]json.RawMessage)
+			}
+			m.options[key] = jsonMap[key]
+			continue
+		}
+
+		if m.strategy != "" {
+			return fmt.Errorf("matcher has more than one strategy: %s, %s", m.strategy, key)
+		}
+
+		m.strategy = ParamMatchingStrategy(key)
+		if _, ok := stringValue(jsonMap[key]); ok || m.strategy == ParamEqualToJson {
+			m.value = matcherValue(jsonMap[key])
+		} else {
+			m.value, m.rawValue = "", jsonMap[key]
+		}
+	}
+
+	if m.strategy == "" && len(keys) > 0 {
+		return fmt.Errorf("matcher has no strategy: %s", strings.Join(keys, ", "))
+	}
+
+	return nil
+}
+
 // Or returns a logical OR of the two matchers.
 func (m StringValueMatcher) Or(matcher BasicParamMatcher) BasicParamMatcher {
 	return Or(m, matcher)
@@ -182,6 +368,51 @@ func (m JSONSchemaMatcher) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON parses the JSON encoding of the matcher.
+func (m *JSONSchemaMatcher) UnmarshalJSON(data []byte) error {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		return err
+	}
+
+	schema, ok := jsonMap[string(ParamMatchesJsonSchema)]
+	if !ok {
+		return fmt.Errorf("matcher has no %s strategy", ParamMatchesJsonSchema)
+	}
+
+	var schemaVersion string
+	if data, ok := jsonMap["schemaVersion"]; ok {
+		if err := json.Unmarshal(data, &schemaVersion); err != nil {
+			return fmt.Errorf("schemaVersion: %w", err)
+		}
+	}
+
+	m.StringValueMatcher = NewStringValueMatcher(ParamMatchesJsonSchema, matcherValue(schema))
+	m.schemaVersion = schemaVersion
+
+	return nil
+}
+
+// stringValue returns the value of a JSON string.
+func stringValue(data json.RawMessage) (string, bool) {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// matcherValue returns the string value of the matcher, other JSON values are returned in their encoded form.
+func matcherValue(data json.RawMessage) string {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		return value
+	}
+
+	return string(data)
+}
+
 func regexContainsStartAnchor(regex string) bool {
 	return len(regex) > 0 && regex[0] == '^'
 }
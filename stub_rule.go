@@ -2,6 +2,7 @@ package wiremock
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -14,6 +15,8 @@ const authorizationHeader = "Authorization"
 // StubRule is struct of http Request body to WireMock
 type StubRule struct {
 	uuid                   string
+	name                   *string
+	persistent             bool
 	request                *Request
 	response               ResponseInterface
 	fixedDelayMilliseconds *int64
@@ -22,6 +25,8 @@ type StubRule struct {
 	requiredScenarioState  *string
 	newScenarioState       *string
 	postServeActions       []WebhookInterface
+	serveEventListeners    []serveEventListener
+	metadata               map[string]interface{}
 }
 
 // NewStubRule returns a new *StubRule.
@@ -39,6 +44,11 @@ func (s *StubRule) Request() *Request {
 	return s.request
 }
 
+// Response is getter for response
+func (s *StubRule) Response() ResponseInterface {
+	return s.response
+}
+
 // WithQueryParam adds query param and returns *StubRule
 func (s *StubRule) WithQueryParam(param string, matcher MatcherInterface) *StubRule {
 	s.request.WithQueryParam(param, matcher)
@@ -195,6 +205,37 @@ func (s *StubRule) WillSetStateTo(scenarioState string) *StubRule {
 	return s
 }
 
+// WithName sets name and returns *StubRule
+func (s *StubRule) WithName(name string) *StubRule {
+	s.name = &name
+	return s
+}
+
+// WithID sets uuid and returns *StubRule
+func (s *StubRule) WithID(uuid string) *StubRule {
+	s.uuid = uuid
+	return s
+}
+
+// Persistent marks the stub to be saved to the backing store and returns *StubRule
+func (s *StubRule) Persistent() *StubRule {
+	s.persistent = true
+	return s
+}
+
+// WithMetadata adds metadata and returns *StubRule
+func (s *StubRule) WithMetadata(metadata map[string]interface{}) *StubRule {
+	if s.metadata == nil {
+		s.metadata = make(map[string]interface{}, len(metadata))
+	}
+
+	for key, value := range metadata {
+		s.metadata[key] = value
+	}
+
+	return s
+}
+
 // UUID is getter for uuid
 func (s *StubRule) UUID() string {
 	return s.uuid
@@ -230,11 +271,31 @@ func (s *StubRule) WithPostServeAction(extensionName string, webhook WebhookInte
 	return s
 }
 
+// WithWebhook adds a webhook post serve action and returns *StubRule.
+// Every call adds another webhook to the stub.
+func (s *StubRule) WithWebhook(webhook WebhookInterface) *StubRule {
+	return s.WithPostServeAction(webhookExtensionName, webhook)
+}
+
+// WithServeEventListener adds a serve event listener called in the given request phases and returns *StubRule.
+// All phases of the listener are used when phases are empty, e.g. AFTER_COMPLETE for webhooks.
+// Required wiremock >= 3.0.0
+func (s *StubRule) WithServeEventListener(name string, phases []RequestPhase, params map[string]interface{}) *StubRule {
+	s.serveEventListeners = append(s.serveEventListeners, serveEventListener{
+		name:          name,
+		requestPhases: phases,
+		parameters:    params,
+	})
+	return s
+}
+
 // MarshalJSON makes json body for http Request
 func (s *StubRule) MarshalJSON() ([]byte, error) {
 	jsonStubRule := struct {
 		UUID                          string                 `json:"uuid,omitempty"`
 		ID                            string                 `json:"id,omitempty"`
+		Name                          *string                `json:"name,omitempty"`
+		Persistent                    bool                   `json:"persistent,omitempty"`
 		Priority                      *int64                 `json:"priority,omitempty"`
 		ScenarioName                  *string                `json:"scenarioName,omitempty"`
 		RequiredScenarioScenarioState *string                `json:"requiredScenarioState,omitempty"`
@@ -242,14 +303,20 @@ func (s *StubRule) MarshalJSON() ([]byte, error) {
 		Request                       *Request               `json:"request"`
 		Response                      map[string]interface{} `json:"response"`
 		PostServeActions              []WebhookInterface     `json:"postServeActions,omitempty"`
+		ServeEventListeners           []serveEventListener   `json:"serveEventListeners,omitempty"`
+		Metadata                      map[string]interface{} `json:"metadata,omitempty"`
 	}{}
 
+	jsonStubRule.Name = s.name
+	jsonStubRule.Persistent = s.persistent
 	jsonStubRule.Priority = s.priority
 	jsonStubRule.ScenarioName = s.scenarioName
 	jsonStubRule.RequiredScenarioScenarioState = s.requiredScenarioState
 	jsonStubRule.NewScenarioState = s.newScenarioState
 	jsonStubRule.Response = s.response.ParseResponse()
 	jsonStubRule.PostServeActions = s.postServeActions
+	jsonStubRule.ServeEventListeners = s.serveEventListeners
+	jsonStubRule.Metadata = s.metadata
 
 	if s.fixedDelayMilliseconds != nil {
 		jsonStubRule.Response["fixedDelayMilliseconds"] = *s.fixedDelayMilliseconds
@@ -262,6 +329,69 @@ func (s *StubRule) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonStubRule)
 }
 
+// UnmarshalJSON parses the JSON encoding of a stub mapping.
+// A new uuid is generated when the mapping has no id.
+func (s *StubRule) UnmarshalJSON(data []byte) error {
+	jsonStubRule := struct {
+		UUID                          string                 `json:"uuid"`
+		ID                            string                 `json:"id"`
+		Name                          *string                `json:"name"`
+		Persistent                    bool                   `json:"persistent"`
+		Priority                      *int64                 `json:"priority"`
+		ScenarioName                  *string                `json:"scenarioName"`
+		RequiredScenarioScenarioState *string                `json:"requiredScenarioState"`
+		NewScenarioState              *string                `json:"newScenarioState"`
+		Request                       *Request               `json:"request"`
+		Response                      Response               `json:"response"`
+		PostServeActions              json.RawMessage        `json:"postServeActions"`
+		ServeEventListeners           []serveEventListener   `json:"serveEventListeners"`
+		Metadata                      map[string]interface{} `json:"metadata"`
+	}{
+		Response: NewResponse(),
+	}
+	if err := json.Unmarshal(data, &jsonStubRule); err != nil {
+		return err
+	}
+
+	uuid := jsonStubRule.ID
+	if uuid == "" {
+		uuid = jsonStubRule.UUID
+	}
+	if uuid == "" {
+		newUUID, _ := uuidPkg.NewRandom()
+		uuid = newUUID.String()
+	}
+
+	request := jsonStubRule.Request
+	if request == nil {
+		request = NewRequest("ANY", nil)
+	}
+
+	*s = StubRule{
+		uuid:                  uuid,
+		name:                  jsonStubRule.Name,
+		persistent:            jsonStubRule.Persistent,
+		request:               request,
+		response:              jsonStubRule.Response,
+		priority:              jsonStubRule.Priority,
+		scenarioName:          jsonStubRule.ScenarioName,
+		requiredScenarioState: jsonStubRule.RequiredScenarioScenarioState,
+		newScenarioState:      jsonStubRule.NewScenarioState,
+		serveEventListeners:   jsonStubRule.ServeEventListeners,
+		metadata:              jsonStubRule.Metadata,
+	}
+
+	if len(jsonStubRule.PostServeActions) > 0 && string(jsonStubRule.PostServeActions) != "null" {
+		postServeActions, err := unmarshalPostServeActions(jsonStubRule.PostServeActions)
+		if err != nil {
+			return fmt.Errorf("postServeActions: %w", err)
+		}
+		s.postServeActions = postServeActions
+	}
+
+	return nil
+}
+
 func addAuthMethodToMatcher(matcher BasicParamMatcher, methodPrefix string) BasicParamMatcher {
 	switch m := matcher.(type) {
 	case StringValueMatcher:
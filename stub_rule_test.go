@@ -164,6 +164,50 @@ func TestStubRule_ToJson(t *testing.T) {
 						WithTransformerParameter("MyCustomParameter", "Parameter Value")),
 			ExpectedFileName: "expected-template-transformerParameters.json",
 		},
+		{
+			Name: "StubRuleWithMetadata",
+			StubRule: Get(URLPathEqualTo("/example")).
+				WithMetadata(map[string]interface{}{"owner": "team-a"}).
+				WithMetadata(map[string]interface{}{"tags": []string{"smoke", "billing"}}).
+				WillReturnResponse(OK()),
+			ExpectedFileName: "expected-template-metadata.json",
+		},
+		{
+			Name: "NamedPersistentStubRule",
+			StubRule: Get(URLPathEqualTo("/example")).
+				WithName("Example stub").
+				WithID("4c3f8d2e-5b7a-4f1e-9a6d-2e8b1c0f7a53").
+				Persistent().
+				WillReturnResponse(OK()),
+			ExpectedFileName: "expected-template-named-persistent.json",
+		},
+		{
+			Name: "ServeEventListeners",
+			StubRule: Post(URLPathEqualTo("/orders")).
+				WithWebhook(NewWebhook().
+					WithMethod("POST").
+					WithURL("http://my-target-host/callback").
+					WithBodyTemplate(`{"id": "{{jsonPath originalRequest.body '$.id'}}"}`)).
+				WithServeEventListener("webhook", []RequestPhase{RequestPhaseAfterComplete}, NewWebhook().
+					WithMethod("PUT").
+					WithURL("http://my-target-host/binary").
+					WithHeader("Content-Type", "application/octet-stream").
+					WithBase64Body([]byte{0x00, 0x01}).
+					ParseParameters()).
+				WillReturnResponse(NewResponse().WithStatus(http.StatusCreated)),
+			ExpectedFileName: "expected-template-serve-event-listeners.json",
+		},
+		{
+			Name: "ProxiedResponse",
+			StubRule: NewStubRule("ANY", URLPathMatching("/other/service/.*")).
+				AtPriority(10).
+				WillReturnResponse(NewResponse().
+					ProxiedFrom("http://localhost:9090").
+					WithAdditionalProxyRequestHeader("X-Forwarded-By", "wiremock").
+					WithRemoveProxyRequestHeaders("Authorization", "Cookie").
+					WithProxyURLPrefixToRemove("/other/service")),
+			ExpectedFileName: "expected-template-proxy.json",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -198,3 +242,306 @@ func TestStubRule_ToJson(t *testing.T) {
 		})
 	}
 }
+
+func TestStubRule_UnmarshalJSON(t *testing.T) {
+	const uuid = "0f3a1a3c-7c4c-4a8e-9d8f-1c2b3d4e5f60"
+
+	files, err := filepath.Glob(filepath.Join(testDataDir, "*.json"))
+	if err != nil {
+		t.Fatalf("failed to list test data: %v", err)
+	}
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			rawExpected, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("failed to read JSON file %s: %v", file, err)
+			}
+			expected := []byte(fmt.Sprintf(string(rawExpected), uuid, uuid))
+
+			var stubRule StubRule
+			err = json.Unmarshal(expected, &stubRule)
+			if err != nil {
+				t.Fatalf("StubRule json.Unmarshal error: %v", err)
+			}
+
+			if stubRule.UUID() != uuid {
+				t.Errorf("expected uuid %s, got %s", uuid, stubRule.UUID())
+			}
+
+			assertJSONEqual(t, expected, &stubRule)
+		})
+	}
+}
+
+func TestStubRule_UnmarshalJSON_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		StubRule *StubRule
+	}{
+		{
+			Name: "LogNormalRandomDelay",
+			StubRule: Get(URLEqualTo("/delay?type=lognormal")).
+				WillReturnResponse(NewResponse().WithLogNormalRandomDelay(90*time.Millisecond, 0.1)),
+		},
+		{
+			Name: "UniformRandomDelay",
+			StubRule: Get(URLPathMatching("/delay/.*")).
+				WillReturnResponse(NewResponse().WithUniformRandomDelay(15*time.Millisecond, 25*time.Millisecond)),
+		},
+		{
+			Name: "ChunkedDribbleDelay",
+			StubRule: Get(URLPathEqualTo("/dribble")).
+				WillReturnResponse(NewResponse().WithChunkedDribbleDelay(5, time.Second)),
+		},
+		{
+			Name: "Bodies",
+			StubRule: Put(URLPathEqualTo("/bodies")).
+				WithAuthToken(Matching("t?o?ken$")).
+				WithQueryParam("exact", HasExactly(EqualTo("1"), Absent())).
+				WithBodyPattern(MatchingXPath("//todo-item")).
+				WithBodyPattern(MatchingJsonPath("$.name")).
+				WithBodyPattern(Not(EqualToIgnoreCase("none"))).
+				WillReturnResponse(NewResponse().
+					WithBinaryBody([]byte{0x00, 0x01, 0xfe}).
+					WithJSONBody(map[string]interface{}{"items": []interface{}{"a", "b"}}).
+					WithBodyFile("body.json").
+					WithFault(FaultMalformedResponseChunk)),
+		},
+		{
+			Name: "PostServeActions",
+			StubRule: Delete(URLPathEqualTo("/webhooks")).
+				WithPostServeAction("webhook", NewWebhook().
+					WithMethod("DELETE").
+					WithURL("http://my-target-host/callback").
+					WithLogNormalRandomDelay(time.Second, 0.2)).
+				WithPostServeAction("webhook", NewWebhook().
+					WithMethod("POST").
+					WithURL("http://my-other-host/callback").
+					WithUniformRandomDelay(time.Second, 2*time.Second)),
+		},
+		{
+			Name: "ServeEventListeners",
+			StubRule: Post(URLPathEqualTo("/listeners")).
+				WithWebhook(NewWebhook().WithURL("http://my-target-host/callback")).
+				WithServeEventListener("webhook", []RequestPhase{RequestPhaseAfterComplete}, NewWebhook().
+					WithMethod("PUT").
+					WithURL("http://my-target-host/binary").
+					WithBase64Body([]byte{0x00, 0x01}).
+					ParseParameters()),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			expected, err := json.Marshal(tc.StubRule)
+			if err != nil {
+				t.Fatalf("StubRule json.Marshal error: %v", err)
+			}
+
+			var stubRule StubRule
+			err = json.Unmarshal(expected, &stubRule)
+			if err != nil {
+				t.Fatalf("StubRule json.Unmarshal error: %v", err)
+			}
+
+			assertJSONEqual(t, expected, &stubRule)
+		})
+	}
+}
+
+func TestStubRule_UnmarshalJSON_WireMockFormats(t *testing.T) {
+	var stubRule StubRule
+	err := json.Unmarshal([]byte(`{
+		"request": {
+			"urlPath": "/example",
+			"bodyPatterns": [{"equalToJson": {"meta": "information"}, "ignoreExtraElements": true}]
+		},
+		"response": {"fixedDelayMilliseconds": 500},
+		"postServeActions": {
+			"webhook": {
+				"method": "POST",
+				"url": "http://my-target-host/callback",
+				"delay": {"type": "fixed", "milliseconds": 100}
+			}
+		}
+	}`), &stubRule)
+	if err != nil {
+		t.Fatalf("StubRule json.Unmarshal error: %v", err)
+	}
+
+	if stubRule.UUID() == "" {
+		t.Fatal("expected generated uuid")
+	}
+
+	expected := fmt.Sprintf(`{
+		"id": "%[1]s",
+		"uuid": "%[1]s",
+		"request": {
+			"method": "ANY",
+			"urlPath": "/example",
+			"bodyPatterns": [{"equalToJson": "{\"meta\": \"information\"}", "ignoreExtraElements": true}]
+		},
+		"response": {
+			"status": 200,
+			"delayDistribution": {"type": "fixed", "milliseconds": 500}
+		},
+		"postServeActions": [{
+			"name": "webhook",
+			"parameters": {
+				"method": "POST",
+				"url": "http://my-target-host/callback",
+				"delay": {"type": "fixed", "milliseconds": 100}
+			}
+		}]
+	}`, stubRule.UUID())
+
+	assertJSONEqual(t, []byte(expected), &stubRule)
+}
+
+func TestStubRule_UnmarshalJSON_UnknownPostServeActions(t *testing.T) {
+	stub := `{
+		"id": "a1c0d7b2-3f4e-4a5b-8c6d-7e8f9a0b1c2d",
+		"uuid": "a1c0d7b2-3f4e-4a5b-8c6d-7e8f9a0b1c2d",
+		"request": {"method": "POST", "urlPath": "/example"},
+		"response": {"status": 200},
+		"postServeActions": [{
+			"name": "webhook",
+			"parameters": {
+				"method": "POST",
+				"url": "http://my-target-host/callback",
+				"headers": {"X-Values": ["first", "second"]},
+				"extraParameter": {"nested": [1, 2]}
+			}
+		}, {
+			"name": "audit-log",
+			"parameters": {"level": "debug"}
+		}]
+	}`
+
+	var stubRule StubRule
+	if err := json.Unmarshal([]byte(stub), &stubRule); err != nil {
+		t.Fatalf("StubRule json.Unmarshal error: %v", err)
+	}
+
+	assertJSONEqual(t, []byte(stub), &stubRule)
+}
+
+func TestStubRule_UnmarshalJSON_MatcherObjects(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Pattern string
+	}{
+		{
+			Name:    "MatchesJsonPathSubMatcher",
+			Pattern: `{"matchesJsonPath": {"expression": "$.x", "equalTo": "y"}}`,
+		},
+		{
+			Name:    "MatchesJsonPathSubMatcherFlags",
+			Pattern: `{"matchesJsonPath": {"expression": "$.items", "equalToJson": "[1, 2]", "ignoreArrayOrder": true}}`,
+		},
+		{
+			Name:    "MatchesXPathNamespaces",
+			Pattern: `{"matchesXPath": "//s:name", "xPathNamespaces": {"s": "https://example.com/stuff"}}`,
+		},
+		{
+			Name:    "MatchesXPathSubMatcher",
+			Pattern: `{"matchesXPath": {"expression": "//name/text()", "contains": "Tom"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			data := fmt.Sprintf(`{
+				"id": "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7",
+				"uuid": "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7",
+				"request": {
+					"method": "POST",
+					"urlPath": "/matchers",
+					"bodyPatterns": [%s]
+				},
+				"response": {"status": 200}
+			}`, tc.Pattern)
+
+			var stubRule StubRule
+			if err := json.Unmarshal([]byte(data), &stubRule); err != nil {
+				t.Fatalf("StubRule json.Unmarshal error: %v", err)
+			}
+
+			assertJSONEqual(t, []byte(data), &stubRule)
+		})
+	}
+}
+
+func TestStubRule_UnmarshalJSON_ResponseValues(t *testing.T) {
+	data := `{
+		"id": "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7",
+		"uuid": "8c5db8b0-2db4-4ad7-a99f-38c9b00da3f7",
+		"request": {"method": "GET", "urlPath": "/cookies"},
+		"response": {
+			"status": 200,
+			"headers": {"Set-Cookie": ["a=1", "b=2"], "Content-Type": "text/plain"},
+			"transformers": ["response-template"],
+			"transformerParameters": {"n": 1, "enabled": true, "nested": {"name": "value"}}
+		}
+	}`
+
+	var stubRule StubRule
+	if err := json.Unmarshal([]byte(data), &stubRule); err != nil {
+		t.Fatalf("StubRule json.Unmarshal error: %v", err)
+	}
+
+	assertJSONEqual(t, []byte(data), &stubRule)
+
+	built := Get(URLPathEqualTo("/cookies")).
+		WillReturnResponse(NewResponse().
+			WithHeaderValues("Set-Cookie", "a=1", "b=2").
+			WithHeader("Content-Type", "text/plain").
+			WithTransformers("response-template").
+			WithTransformerParameter("name", "value"))
+	response := built.Response().ParseResponse()
+	assertJSONEqual(t, []byte(`{"Set-Cookie": ["a=1", "b=2"], "Content-Type": "text/plain"}`), jsonValue{response["headers"]})
+}
+
+func TestResponse_WithAdditionalProxyRequestHeader_Copies(t *testing.T) {
+	base := NewResponse().
+		ProxiedFrom("http://other-service").
+		WithAdditionalProxyRequestHeader("X-Forwarded-By", "wiremock")
+
+	first := base.WithAdditionalProxyRequestHeader("X-Tenant", "first")
+	second := base.WithAdditionalProxyRequestHeader("X-Tenant", "second")
+
+	assertJSONEqual(t, []byte(`{"X-Forwarded-By": "wiremock"}`), jsonValue{base.ParseResponse()["additionalProxyRequestHeaders"]})
+	assertJSONEqual(t, []byte(`{"X-Forwarded-By": "wiremock", "X-Tenant": "first"}`), jsonValue{first.ParseResponse()["additionalProxyRequestHeaders"]})
+	assertJSONEqual(t, []byte(`{"X-Forwarded-By": "wiremock", "X-Tenant": "second"}`), jsonValue{second.ParseResponse()["additionalProxyRequestHeaders"]})
+}
+
+// jsonValue marshals any value, so it can be compared with assertJSONEqual.
+type jsonValue struct {
+	value interface{}
+}
+
+func (v jsonValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.value)
+}
+
+func assertJSONEqual(t *testing.T, expected []byte, actual json.Marshaler) {
+	t.Helper()
+
+	rawActual, err := actual.MarshalJSON()
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+
+	var parsedExpected, parsedActual interface{}
+	if err := json.Unmarshal(expected, &parsedExpected); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+	if err := json.Unmarshal(rawActual, &parsedActual); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+
+	if !reflect.DeepEqual(parsedExpected, parsedActual) {
+		t.Errorf("expected JSON:\n%s\nactual JSON:\n%s", expected, rawActual)
+	}
+}
@@ -0,0 +1,144 @@
+package wiremock
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wiremock/go-wiremock/journal"
+)
+
+const (
+	// maxVerificationNearMisses limits the near misses described by VerificationError.
+	maxVerificationNearMisses = 3
+	// maxVerificationRequests limits the received requests fetched and described by VerificationError.
+	maxVerificationRequests = 10
+	// defaultPollInterval is the interval between journal polls of WaitForRequests.
+	defaultPollInterval = 100 * time.Millisecond
+	// verificationDiagnosticsTimeout limits collecting VerificationError details after the context of VerifyEventually ended.
+	verificationDiagnosticsTimeout = 5 * time.Second
+)
+
+// CountMatcher checks the number of received requests.
+type CountMatcher interface {
+	Match(count int64) bool
+	String() string
+}
+
+type countMatcher struct {
+	description string
+	match       func(count int64) bool
+}
+
+// Match reports whether count satisfies the matcher.
+func (m countMatcher) Match(count int64) bool {
+	return m.match(count)
+}
+
+// String returns the description of the matcher.
+func (m countMatcher) String() string {
+	return m.description
+}
+
+// Exactly returns a matcher that matches when exactly n requests were received.
+func Exactly(n int64) CountMatcher {
+	return countMatcher{
+		description: fmt.Sprintf("exactly %d", n),
+		match:       func(count int64) bool { return count == n },
+	}
+}
+
+// AtLeast returns a matcher that matches when n or more requests were received.
+func AtLeast(n int64) CountMatcher {
+	return countMatcher{
+		description: fmt.Sprintf("at least %d", n),
+		match:       func(count int64) bool { return count >= n },
+	}
+}
+
+// AtMost returns a matcher that matches when n or less requests were received.
+func AtMost(n int64) CountMatcher {
+	return countMatcher{
+		description: fmt.Sprintf("at most %d", n),
+		match:       func(count int64) bool { return count <= n },
+	}
+}
+
+// Between returns a matcher that matches when between lower and upper requests, inclusive, were received.
+func Between(lower, upper int64) CountMatcher {
+	return countMatcher{
+		description: fmt.Sprintf("between %d and %d", lower, upper),
+		match:       func(count int64) bool { return count >= lower && count <= upper },
+	}
+}
+
+// Never returns a matcher that matches when no requests were received.
+func Never() CountMatcher {
+	return countMatcher{
+		description: "no",
+		match:       func(count int64) bool { return count == 0 },
+	}
+}
+
+// VerificationError is returned when the number of received requests doesn't satisfy the CountMatcher.
+type VerificationError struct {
+	Pattern    string
+	Expected   CountMatcher
+	Count      int64
+	NearMisses []journal.NearMiss
+	// Requests are the most recent requests of the journal, TotalRequests counts all of them.
+	Requests      []journal.GetRequestResponse
+	TotalRequests int64
+}
+
+// Error implements the error interface.
+func (e *VerificationError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "expected %s requests matching %s, received %d", e.Expected, e.Pattern, e.Count)
+
+	if len(e.NearMisses) > 0 {
+		sb.WriteString("\n\nClosest near misses:")
+		for i, nearMiss := range e.NearMisses {
+			if i == maxVerificationNearMisses {
+				break
+			}
+			sb.WriteString("\n")
+			sb.WriteString(indent(nearMiss.Format(), "  "))
+		}
+	}
+
+	sb.WriteString("\n\nReceived requests:")
+	if len(e.Requests) == 0 {
+		sb.WriteString("\n  none")
+	}
+	for i, request := range e.Requests {
+		if i == maxVerificationRequests {
+			break
+		}
+		fmt.Fprintf(&sb, "\n  %s %s", request.Request.Method, request.Request.URL)
+	}
+
+	shown := int64(min(len(e.Requests), maxVerificationRequests))
+	if more := max(e.TotalRequests, int64(len(e.Requests))) - shown; more > 0 {
+		fmt.Fprintf(&sb, "\n  ... and %d more", more)
+	}
+
+	return sb.String()
+}
+
+// describe returns the method and the URL pattern of the request.
+func (r *Request) describe() string {
+	if r.urlMatcher == nil {
+		return r.method
+	}
+
+	return fmt.Sprintf("%s %s %s", r.method, r.urlMatcher.Strategy(), r.urlMatcher.Value())
+}
+
+func indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,129 @@
+package wiremock
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wiremock/go-wiremock/journal"
+)
+
+func TestCountMatcher(t *testing.T) {
+	testCases := []struct {
+		name        string
+		matcher     CountMatcher
+		description string
+		matches     []int64
+		mismatches  []int64
+	}{
+		{
+			name:        "Exactly",
+			matcher:     Exactly(2),
+			description: "exactly 2",
+			matches:     []int64{2},
+			mismatches:  []int64{0, 1, 3},
+		},
+		{
+			name:        "AtLeast",
+			matcher:     AtLeast(2),
+			description: "at least 2",
+			matches:     []int64{2, 3},
+			mismatches:  []int64{0, 1},
+		},
+		{
+			name:        "AtMost",
+			matcher:     AtMost(2),
+			description: "at most 2",
+			matches:     []int64{0, 1, 2},
+			mismatches:  []int64{3},
+		},
+		{
+			name:        "Between",
+			matcher:     Between(1, 3),
+			description: "between 1 and 3",
+			matches:     []int64{1, 2, 3},
+			mismatches:  []int64{0, 4},
+		},
+		{
+			name:        "Never",
+			matcher:     Never(),
+			description: "no",
+			matches:     []int64{0},
+			mismatches:  []int64{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.matcher.String() != tc.description {
+				t.Errorf("expected description %q, got %q", tc.description, tc.matcher.String())
+			}
+
+			for _, count := range tc.matches {
+				if !tc.matcher.Match(count) {
+					t.Errorf("expected %d to match", count)
+				}
+			}
+
+			for _, count := range tc.mismatches {
+				if tc.matcher.Match(count) {
+					t.Errorf("expected %d not to match", count)
+				}
+			}
+		})
+	}
+}
+
+func TestVerificationError_Error(t *testing.T) {
+	err := &VerificationError{
+		Pattern:  NewRequest("POST", URLPathEqualTo("/orders")).describe(),
+		Expected: Exactly(1),
+		Count:    0,
+		NearMisses: []journal.NearMiss{
+			{
+				Request: journal.Request{Method: "GET", URL: "/orders"},
+				RequestPattern: &journal.StubMappingRequest{
+					Method:  "POST",
+					URLPath: "/orders",
+				},
+			},
+		},
+		Requests: []journal.GetRequestResponse{
+			{Request: journal.Request{Method: "GET", URL: "/orders"}},
+		},
+	}
+
+	message := err.Error()
+	for _, expected := range []string{
+		"expected exactly 1 requests matching POST urlPath /orders, received 0",
+		"Closest near misses:",
+		"does not match",
+		"Received requests:\n  GET /orders",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("expected message to contain %q, got:\n%s", expected, message)
+		}
+	}
+}
+
+func TestVerificationError_Error_RequestsLimit(t *testing.T) {
+	requests := make([]journal.GetRequestResponse, maxVerificationRequests+2)
+	for i := range requests {
+		requests[i] = journal.GetRequestResponse{Request: journal.Request{Method: "GET", URL: fmt.Sprintf("/orders/%d", i)}}
+	}
+
+	err := &VerificationError{
+		Pattern:       NewRequest("POST", URLPathEqualTo("/orders")).describe(),
+		Expected:      Exactly(1),
+		Requests:      requests,
+		TotalRequests: 1000,
+	}
+
+	message := err.Error()
+	if strings.Contains(message, fmt.Sprintf("/orders/%d", maxVerificationRequests)) {
+		t.Errorf("expected at most %d requests, got:\n%s", maxVerificationRequests, message)
+	}
+	if !strings.Contains(message, fmt.Sprintf("\n  ... and %d more", 1000-maxVerificationRequests)) {
+		t.Errorf("expected the number of requests not shown, got:\n%s", message)
+	}
+}
@@ -1,7 +1,11 @@
 package wiremock
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
 	"time"
 )
 
@@ -17,23 +21,104 @@ type Webhook struct {
 }
 
 type webhookParameters struct {
-	method  string
-	url     string
-	body    string
-	headers map[string]string
-	delay   DelayInterface
+	method     string
+	url        string
+	body       string
+	base64Body string
+	headers    map[string]string
+	delay      DelayInterface
+
+	// extra keeps the parameters which aren't modelled, so they are written back unchanged.
+	extra map[string]json.RawMessage
+}
+
+// webhookParameterKeys are the parameters modelled by webhookParameters.
+var webhookParameterKeys = []string{"method", "url", "body", "base64Body", "headers", "delay"}
+
+// parse returns a map representation of the parameters, unset fields are omitted.
+func (w webhookParameters) parse() map[string]interface{} {
+	jsonMap := make(map[string]interface{}, len(w.extra))
+	for key, value := range w.extra {
+		jsonMap[key] = value
+	}
+
+	if w.method != "" {
+		jsonMap["method"] = w.method
+	}
+	if w.url != "" {
+		jsonMap["url"] = w.url
+	}
+	if w.body != "" {
+		jsonMap["body"] = w.body
+	}
+	if w.base64Body != "" {
+		jsonMap["base64Body"] = w.base64Body
+	}
+	if len(w.headers) > 0 {
+		jsonMap["headers"] = w.headers
+	}
+	if w.delay != nil {
+		jsonMap["delay"] = w.delay.ParseDelay()
+	}
+
+	return jsonMap
 }
 
 func (w webhookParameters) MarshalJSON() ([]byte, error) {
-	jsonMap := map[string]interface{}{
-		"method":  w.method,
-		"url":     w.url,
-		"body":    w.body,
-		"headers": w.headers,
-		"delay":   w.delay.ParseDelay(),
+	return json.Marshal(w.parse())
+}
+
+// UnmarshalJSON parses the JSON encoding of the webhook parameters.
+// Headers which aren't plain strings and unknown parameters are kept as they are.
+func (w *webhookParameters) UnmarshalJSON(data []byte) error {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &jsonMap); err != nil {
+		return err
 	}
 
-	return json.Marshal(jsonMap)
+	var jsonParameters struct {
+		Method     string `json:"method"`
+		URL        string `json:"url"`
+		Body       string `json:"body"`
+		Base64Body string `json:"base64Body"`
+	}
+	if err := json.Unmarshal(data, &jsonParameters); err != nil {
+		return err
+	}
+
+	*w = webhookParameters{
+		method:     jsonParameters.Method,
+		url:        jsonParameters.URL,
+		body:       jsonParameters.Body,
+		base64Body: jsonParameters.Base64Body,
+	}
+
+	if headers, ok := jsonMap["headers"]; ok {
+		if err := json.Unmarshal(headers, &w.headers); err != nil {
+			w.headers = nil
+		} else {
+			delete(jsonMap, "headers")
+		}
+	}
+
+	if delay, ok := jsonMap["delay"]; ok && string(delay) != "null" {
+		parsed, err := unmarshalDelay(delay)
+		if err != nil {
+			return fmt.Errorf("delay: %w", err)
+		}
+		w.delay = parsed
+	}
+
+	for _, key := range webhookParameterKeys {
+		if key != "headers" {
+			delete(jsonMap, key)
+		}
+	}
+	if len(jsonMap) > 0 {
+		w.extra = jsonMap
+	}
+
+	return nil
 }
 
 // WithName sets the name of the webhook and returns the webhook.
@@ -50,11 +135,100 @@ func (w Webhook) ParseWebhook() map[string]interface{} {
 	}
 }
 
+// ParseParameters returns a map representation of the webhook parameters,
+// e.g. to register the webhook with StubRule.WithServeEventListener.
+func (w Webhook) ParseParameters() map[string]interface{} {
+	return w.parameters.parse()
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (w Webhook) MarshalJSON() ([]byte, error) {
 	return json.Marshal(w.ParseWebhook())
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (w *Webhook) UnmarshalJSON(data []byte) error {
+	var jsonWebhook struct {
+		Name       string            `json:"name"`
+		Parameters webhookParameters `json:"parameters"`
+	}
+	if err := json.Unmarshal(data, &jsonWebhook); err != nil {
+		return err
+	}
+
+	w.name = jsonWebhook.Name
+	w.parameters = jsonWebhook.Parameters
+
+	return nil
+}
+
+// rawPostServeAction keeps a post serve action of another extension as it is, so it's written back unchanged.
+type rawPostServeAction struct {
+	name       string
+	parameters json.RawMessage
+}
+
+// WithName sets the name of the action and returns the action.
+func (a rawPostServeAction) WithName(name string) WebhookInterface {
+	a.name = name
+	return a
+}
+
+// ParseWebhook returns a map representation of the action.
+func (a rawPostServeAction) ParseWebhook() map[string]interface{} {
+	jsonMap := map[string]interface{}{"name": a.name}
+	if len(a.parameters) > 0 {
+		jsonMap["parameters"] = a.parameters
+	}
+
+	return jsonMap
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a rawPostServeAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ParseWebhook())
+}
+
+// unmarshalPostServeActions parses post serve actions, both as a list of named actions
+// and as an object keyed by the action name used by WireMock 2.
+// Actions of other extensions than webhook are kept as they are.
+func unmarshalPostServeActions(data []byte) ([]WebhookInterface, error) {
+	type jsonAction struct {
+		Name       string          `json:"name"`
+		Parameters json.RawMessage `json:"parameters"`
+	}
+
+	var actions []jsonAction
+	if err := json.Unmarshal(data, &actions); err != nil {
+		var namedParameters map[string]json.RawMessage
+		if mapErr := json.Unmarshal(data, &namedParameters); mapErr != nil {
+			return nil, err
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(namedParameters)) {
+			actions = append(actions, jsonAction{Name: name, Parameters: namedParameters[name]})
+		}
+	}
+
+	postServeActions := make([]WebhookInterface, len(actions))
+	for i, action := range actions {
+		if action.Name != webhookExtensionName {
+			postServeActions[i] = rawPostServeAction{name: action.Name, parameters: action.Parameters}
+			continue
+		}
+
+		webhook := Webhook{name: action.Name}
+		if len(action.Parameters) > 0 && string(action.Parameters) != "null" {
+			if err := json.Unmarshal(action.Parameters, &webhook.parameters); err != nil {
+				return nil, fmt.Errorf("%s: %w", action.Name, err)
+			}
+		}
+		postServeActions[i] = webhook
+	}
+
+	return postServeActions, nil
+}
+
 // WithMethod sets the HTTP method of the webhook.
 func (w Webhook) WithMethod(method string) Webhook {
 	w.parameters.method = method
@@ -81,6 +255,23 @@ func (w Webhook) WithHeader(key string, value string) Webhook {
 // WithBody sets the body of the webhook.
 func (w Webhook) WithBody(body string) Webhook {
 	w.parameters.body = body
+	w.parameters.base64Body = ""
+	return w
+}
+
+// WithBodyTemplate sets the body of the webhook as a Handlebars template,
+// rendered by WireMock with the originalRequest and the parameters of the webhook,
+// e.g. `{"id": "{{jsonPath originalRequest.body '$.id'}}"}`.
+// It is equivalent to WithBody, as WireMock 3 renders every webhook body as a template
+// and nothing marks the body as one.
+func (w Webhook) WithBodyTemplate(template string) Webhook {
+	return w.WithBody(template)
+}
+
+// WithBase64Body sets the binary body of the webhook, sent base64 encoded to WireMock.
+func (w Webhook) WithBase64Body(body []byte) Webhook {
+	w.parameters.base64Body = base64.StdEncoding.EncodeToString(body)
+	w.parameters.body = ""
 	return w
 }
 
@@ -108,6 +299,7 @@ func (w Webhook) WithUniformRandomDelay(lower, upper time.Duration) Webhook {
 	return w
 }
 
+// NewWebhook returns a new Webhook, register it with StubRule.WithWebhook.
 func NewWebhook() Webhook {
 	return Webhook{}
 }
@@ -0,0 +1,159 @@
+package webhooktest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+	"github.com/wiremock/go-wiremock/journal"
+)
+
+// Expectation describes a webhook call expected by the server.
+//
+// The calls are matched with the same matchers as stubs, evaluated locally, including equalToJson,
+// matchesJsonPath, logical and multi value matchers. Within fails straight away for the matchers
+// evaluated only by WireMock, e.g. equalToXml or matchesXPath.
+type Expectation struct {
+	server  *Server
+	request *wiremock.Request
+}
+
+// Expect returns an expectation of a call with the method and the URL.
+func (s *Server) Expect(method string, urlMatcher wiremock.URLMatcherInterface) *Expectation {
+	return &Expectation{
+		server:  s,
+		request: wiremock.NewRequest(method, urlMatcher),
+	}
+}
+
+// WithHeader adds a header matcher to the expectation.
+func (e *Expectation) WithHeader(header string, matcher wiremock.MatcherInterface) *Expectation {
+	e.request.WithHeader(header, matcher)
+	return e
+}
+
+// WithQueryParam adds a query parameter matcher to the expectation.
+func (e *Expectation) WithQueryParam(param string, matcher wiremock.MatcherInterface) *Expectation {
+	e.request.WithQueryParam(param, matcher)
+	return e
+}
+
+// WithFormParameter adds a form parameter matcher to the expectation.
+func (e *Expectation) WithFormParameter(param string, matcher wiremock.BasicParamMatcher) *Expectation {
+	e.request.WithFormParameter(param, matcher)
+	return e
+}
+
+// WithCookie adds a cookie matcher to the expectation.
+func (e *Expectation) WithCookie(cookie string, matcher wiremock.BasicParamMatcher) *Expectation {
+	e.request.WithCookie(cookie, matcher)
+	return e
+}
+
+// WithBodyPattern adds a body matcher to the expectation.
+func (e *Expectation) WithBodyPattern(matcher wiremock.BasicParamMatcher) *Expectation {
+	e.request.WithBodyPattern(matcher)
+	return e
+}
+
+// Within waits until a matching call is received and returns it.
+// When no call matches in time, the error describes how the closest call differs from the expectation.
+// An error is returned without waiting when the expectation has a matcher which can't be evaluated locally.
+func (e *Expectation) Within(timeout time.Duration) (journal.Request, error) {
+	pattern, err := e.pattern()
+	if err != nil {
+		return journal.Request{}, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		calls, received := e.server.changes()
+		for _, call := range calls {
+			if matches(pattern, call) {
+				return call, nil
+			}
+		}
+
+		select {
+		case <-received:
+		case <-timer.C:
+			return journal.Request{}, e.timeoutError(pattern, timeout, calls)
+		}
+	}
+}
+
+func (e *Expectation) pattern() (journal.StubMappingRequest, error) {
+	data, err := e.request.MarshalJSON()
+	if err != nil {
+		return journal.StubMappingRequest{}, fmt.Errorf("build expectation error: %w", err)
+	}
+
+	var pattern journal.StubMappingRequest
+	if err := json.Unmarshal(data, &pattern); err != nil {
+		return journal.StubMappingRequest{}, fmt.Errorf("build expectation error: %w", err)
+	}
+
+	// Matchers which can't be evaluated are reported as unknown regardless of the request.
+	for _, diff := range (journal.NearMiss{RequestPattern: &pattern}).Diff() {
+		if diff.Status == journal.DiffUnknown {
+			return journal.StubMappingRequest{}, fmt.Errorf("unsupported expectation: %s matcher %q can't be evaluated locally", diff.Field, diff.Expected)
+		}
+	}
+
+	return pattern, nil
+}
+
+func (e *Expectation) timeoutError(pattern journal.StubMappingRequest, timeout time.Duration, calls []journal.Request) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "no matching webhook call received within %s, received %d calls", timeout, len(calls))
+
+	if closest, ok := closestCall(pattern, calls); ok {
+		sb.WriteString("\n\nClosest call:\n")
+		sb.WriteString(closest.Format())
+	}
+
+	return errors.New(sb.String())
+}
+
+func matches(pattern journal.StubMappingRequest, call journal.Request) bool {
+	return compare(pattern, call).MatchResult.Distance == 0
+}
+
+// compare returns the near miss of the call, its distance is the share of the fields not matching the pattern.
+func compare(pattern journal.StubMappingRequest, call journal.Request) journal.NearMiss {
+	nearMiss := journal.NearMiss{Request: call, RequestPattern: &pattern}
+
+	diffs := nearMiss.Diff()
+	var mismatched int
+	for _, diff := range diffs {
+		if diff.Status != journal.DiffMatched {
+			mismatched++
+		}
+	}
+
+	if len(diffs) > 0 {
+		nearMiss.MatchResult.Distance = float64(mismatched) / float64(len(diffs))
+	}
+
+	return nearMiss
+}
+
+func closestCall(pattern journal.StubMappingRequest, calls []journal.Request) (journal.NearMiss, bool) {
+	if len(calls) == 0 {
+		return journal.NearMiss{}, false
+	}
+
+	closest := compare(pattern, calls[0])
+	for _, call := range calls[1:] {
+		if nearMiss := compare(pattern, call); nearMiss.MatchResult.Distance < closest.MatchResult.Distance {
+			closest = nearMiss
+		}
+	}
+
+	return closest, true
+}
@@ -0,0 +1,175 @@
+// Package webhooktest receives webhooks sent by WireMock and asserts them.
+//
+// The server listens on all interfaces, so WireMock running in a container can reach it
+// through the host gateway, e.g. with the "host.docker.internal:host-gateway" extra host:
+//
+//	receiver := webhooktest.NewServer()
+//	defer receiver.Close()
+//
+//	client.StubFor(wiremock.Post(wiremock.URLPathEqualTo("/orders")).
+//		WithWebhook(wiremock.NewWebhook().
+//			WithMethod("POST").
+//			WithURL(receiver.URLForHost(webhooktest.DockerHost) + "/callback")))
+//
+//	call, err := receiver.Expect("POST", wiremock.URLPathEqualTo("/callback")).Within(5 * time.Second)
+package webhooktest
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wiremock/go-wiremock/journal"
+)
+
+// DockerHost is the host name of the Docker host inside containers started with the host gateway.
+const DockerHost = "host.docker.internal"
+
+// Server is an HTTP server recording the webhook calls it receives.
+type Server struct {
+	server *httptest.Server
+	port   string
+
+	mu       sync.Mutex
+	calls    []journal.Request
+	received chan struct{}
+}
+
+// NewServer starts a server listening on all interfaces, the server must be closed by Close.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		panic(fmt.Sprintf("webhooktest: failed to listen on a port: %v", err))
+	}
+
+	s := &Server{received: make(chan struct{})}
+	s.server = httptest.NewUnstartedServer(http.HandlerFunc(s.record))
+	_ = s.server.Listener.Close()
+	s.server.Listener = listener
+	s.server.Start()
+
+	_, s.port, _ = net.SplitHostPort(listener.Addr().String())
+
+	return s
+}
+
+// URL returns the base URL of the server reachable from the local host.
+func (s *Server) URL() string {
+	return s.URLForHost("127.0.0.1")
+}
+
+// URLForHost returns the base URL of the server reachable by the given host name, e.g. DockerHost.
+func (s *Server) URLForHost(host string) string {
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, s.port))
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Calls returns the webhook calls received so far.
+func (s *Server) Calls() []journal.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make([]journal.Request, len(s.calls))
+	copy(calls, s.calls)
+	return calls
+}
+
+// Reset forgets the webhook calls received so far.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = nil
+}
+
+// changes returns the received calls and a channel closed on the next call.
+func (s *Server) changes() ([]journal.Request, <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make([]journal.Request, len(s.calls))
+	copy(calls, s.calls)
+	return calls, s.received
+}
+
+func (s *Server) record(w http.ResponseWriter, r *http.Request) {
+	call, err := newCall(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	close(s.received)
+	s.received = make(chan struct{})
+	s.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// newCall converts the received request to the journal model, so the calls are matched like in WireMock.
+func newCall(r *http.Request) (journal.Request, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return journal.Request{}, fmt.Errorf("read body error: %w", err)
+	}
+
+	headers := journal.Headers{}
+	for name, values := range r.Header {
+		headers[name] = values
+	}
+	headers["Host"] = []string{r.Host}
+
+	cookies := journal.Cookies{}
+	for _, cookie := range r.Cookies() {
+		cookies[cookie.Name] = append(cookies[cookie.Name], cookie.Value)
+	}
+
+	call := journal.Request{
+		URL:         r.URL.RequestURI(),
+		AbsoluteURL: "http://" + r.Host + r.URL.RequestURI(),
+		Method:      r.Method,
+		ClientIP:    r.RemoteAddr,
+		Headers:     headers,
+		Cookies:     cookies,
+		LoggedDate:  time.Now(),
+		Body:        string(body),
+		Protocol:    r.Proto,
+		Scheme:      "http",
+		Host:        r.Host,
+		QueryParams: newParams(r.URL.Query()),
+	}
+
+	if host, port, err := net.SplitHostPort(r.Host); err == nil {
+		call.Host = host
+		call.Port, _ = strconv.ParseInt(port, 10, 64)
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			call.FormParams = newParams(form)
+		}
+	}
+
+	return call, nil
+}
+
+func newParams(values map[string][]string) journal.Params {
+	params := journal.Params{}
+	for key, value := range values {
+		params[key] = journal.Param{Key: key, Values: value}
+	}
+	return params
+}
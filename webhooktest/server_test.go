@@ -0,0 +1,124 @@
+package webhooktest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wiremock/go-wiremock"
+)
+
+func TestServer_Expect(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		post(t, server.URL()+"/callback?event=paid", `{"id": 1}`)
+	}()
+
+	call, err := server.Expect(http.MethodPost, wiremock.URLPathEqualTo("/callback")).
+		WithQueryParam("event", wiremock.EqualTo("paid")).
+		WithHeader("Content-Type", wiremock.Contains("json")).
+		WithBodyPattern(wiremock.Contains(`"id"`)).
+		Within(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body struct {
+		ID int `json:"id"`
+	}
+	if err := call.DecodeJSON(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.ID != 1 {
+		t.Errorf("expected id 1, got %d", body.ID)
+	}
+
+	if len(server.Calls()) != 1 {
+		t.Errorf("expected 1 call, got %d", len(server.Calls()))
+	}
+
+	server.Reset()
+	if len(server.Calls()) != 0 {
+		t.Errorf("expected no calls after reset, got %d", len(server.Calls()))
+	}
+}
+
+func TestServer_Expect_Timeout(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	post(t, server.URL()+"/callback?event=shipped", `{"id": 1}`)
+
+	_, err := server.Expect(http.MethodPost, wiremock.URLPathEqualTo("/callback")).
+		WithQueryParam("event", wiremock.EqualTo("paid")).
+		Within(20 * time.Millisecond)
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	for _, expected := range []string{
+		"no matching webhook call received within 20ms, received 1 calls",
+		"Closest call:",
+		"query event",
+		"<<<<< does not match",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got:\n%s", expected, err.Error())
+		}
+	}
+}
+
+func TestServer_Expect_JSON(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	post(t, server.URL()+"/callback", `{"id": 1, "items": [{"sku": "a"}, {"sku": "b"}]}`)
+
+	_, err := server.Expect(http.MethodPost, wiremock.URLPathEqualTo("/callback")).
+		WithHeader("Content-Type", wiremock.Contains("json").And(wiremock.Contains("app"))).
+		WithBodyPattern(wiremock.EqualToJson(`{"id": 1}`, wiremock.IgnoreExtraElements)).
+		WithBodyPattern(wiremock.MatchingJsonPath(`$.items[?(@.sku == 'b')]`)).
+		Within(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_Expect_Unsupported(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	start := time.Now()
+	_, err := server.Expect(http.MethodPost, wiremock.URLPathEqualTo("/callback")).
+		WithBodyPattern(wiremock.EqualToXml("<id>1</id>")).
+		Within(time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "can't be evaluated locally") {
+		t.Fatalf("expected unsupported expectation error, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected error without waiting, waited %s", elapsed)
+	}
+}
+
+func TestServer_URLForHost(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	if !strings.HasPrefix(server.URLForHost(DockerHost), "http://host.docker.internal:") {
+		t.Errorf("unexpected URL %s", server.URLForHost(DockerHost))
+	}
+}
+
+func post(t *testing.T, url, body string) {
+	res, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_ = res.Body.Close()
+}